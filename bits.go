@@ -0,0 +1,122 @@
+package memio
+
+import (
+	"fmt"
+	"io/fs"
+)
+
+// BitOrder specifies the order in which the bits of each byte are read or written by BitReader and BitWriter
+type BitOrder int
+
+const (
+	// MSBFirst reads and writes the most significant bit of each byte first, and values are stored most significant bit first
+	MSBFirst BitOrder = iota
+	// LSBFirst reads and writes the least significant bit of each byte first, and values are stored least significant bit first, like deflate
+	LSBFirst
+)
+
+// BitReader reads values of 1 to 64 bits from a File
+//
+// It reads whole bytes from the File as needed, and keeps the unread bits of the last one.
+type BitReader struct {
+	File  *File
+	Order BitOrder
+	// cur holds the last byte read, of which the nbits that haven't been read yet are
+	// the low bits if Order is MSBFirst, or the high bits if it's LSBFirst
+	cur   byte
+	nbits int
+}
+
+// NewBitReader returns a new BitReader that reads from f, in MSBFirst order
+func NewBitReader(f *File) *BitReader {
+	return &BitReader{File: f}
+}
+
+// ReadBits reads an n bit value
+//
+// An error wrapping fs.ErrInvalid is returned if n is not in range [0, 64].
+// If fewer than n bits remain, nothing is read and an error wrapping io.ErrUnexpectedEOF is returned.
+func (r *BitReader) ReadBits(n int) (uint64, error) {
+	if n < 0 || n > 64 {
+		return 0, &MemioError{Op: "BitReader.ReadBits", Err: fmt.Errorf("invalid bit count(%d): %w", n, fs.ErrInvalid)}
+	}
+	if r.File.closed {
+		return 0, &MemioError{Op: "BitReader.ReadBits", Err: fs.ErrClosed}
+	}
+	if n > r.nbits+8*r.File.RemainingLen() {
+		return 0, &MemioError{Op: "BitReader.ReadBits", Err: ErrShortBuffer}
+	}
+	v := uint64(0)
+	for got := 0; got < n; {
+		if r.nbits == 0 {
+			r.cur = r.File.consume(1)[0]
+			r.nbits = 8
+		}
+		k := min(n-got, r.nbits)
+		mask := byte(1<<k - 1)
+		if r.Order == LSBFirst {
+			v |= uint64(r.cur>>(8-r.nbits)&mask) << got
+		} else {
+			v = v<<k | uint64(r.cur>>(r.nbits-k)&mask)
+		}
+		r.nbits -= k
+		got += k
+	}
+	return v, nil
+}
+
+// BitWriter writes values of 1 to 64 bits to a File
+//
+// Whole bytes are written to the File as they're filled, Flush must be called to write the final partial byte.
+type BitWriter struct {
+	File  *File
+	Order BitOrder
+	// cur holds the nbits that haven't been written yet,
+	// in the high bits if Order is MSBFirst, or the low bits if it's LSBFirst
+	cur   byte
+	nbits int
+}
+
+// NewBitWriter returns a new BitWriter that writes to f, in MSBFirst order
+func NewBitWriter(f *File) *BitWriter {
+	return &BitWriter{File: f}
+}
+
+// WriteBits writes the low n bits of v
+//
+// It panics if n is not in range [0, 64].
+func (w *BitWriter) WriteBits(v uint64, n int) {
+	if n < 0 || n > 64 {
+		panic("memio: WriteBits: invalid bit count")
+	}
+	if n < 64 {
+		v &= 1<<n - 1
+	}
+	for n > 0 {
+		k := min(n, 8-w.nbits)
+		if w.Order == LSBFirst {
+			w.cur |= byte(v&(1<<k-1)) << w.nbits
+			v >>= k
+		} else {
+			w.cur |= byte(v>>(n-k)&(1<<k-1)) << (8 - w.nbits - k)
+		}
+		w.nbits += k
+		n -= k
+		if w.nbits == 8 {
+			w.File.Expand(1)[0] = w.cur
+			w.cur, w.nbits = 0, 0
+		}
+	}
+}
+
+// Flush writes the final partial byte, if any, padded with zero bits
+func (w *BitWriter) Flush() error {
+	if w.nbits == 0 {
+		return nil
+	}
+	if err := w.File.WriteByte(w.cur); err != nil {
+		return &MemioError{Op: "BitWriter.Flush", Err: err}
+	}
+	w.cur, w.nbits = 0, 0
+	return nil
+}
@@ -0,0 +1,72 @@
+package memio
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+)
+
+func TestBits(t *testing.T) {
+	values := []struct {
+		v uint64
+		n int
+	}{
+		{1, 1},
+		{0b101, 3},
+		{0x1ff, 9},
+		{0, 0},
+		{0xdeadbeefcafebabe, 64},
+		{0x2a, 7},
+	}
+	for _, order := range []BitOrder{MSBFirst, LSBFirst} {
+		f := &File{}
+		w := NewBitWriter(f)
+		w.Order = order
+		for _, c := range values {
+			w.WriteBits(c.v, c.n)
+		}
+		w.WriteBits(0xff, 2) // only the low bits are written
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if exp, got := (1+3+9+64+7+2+7)/8, f.Len(); got != exp {
+			t.Fatalf("order=%d: Expected %d bytes; Got %d", order, exp, got)
+		}
+
+		r := NewBitReader(f.Rewind())
+		r.Order = order
+		for _, c := range values {
+			if v, err := r.ReadBits(c.n); err != nil || v != c.v {
+				t.Fatalf("order=%d: Expected (%#x, nil); Got (%#x, %v)", order, c.v, v, err)
+			}
+		}
+		if v, err := r.ReadBits(2); err != nil || v != 3 {
+			t.Fatalf("order=%d: Expected (3, nil); Got (%#x, %v)", order, v, err)
+		}
+		if _, err := r.ReadBits(3); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("order=%d: Expected io.ErrUnexpectedEOF; Got %v", order, err)
+		}
+		if v, err := r.ReadBits(2); err != nil || v != 0 {
+			t.Fatalf("order=%d: Expected the padding (0, nil); Got (%#x, %v)", order, v, err)
+		}
+	}
+}
+
+func TestBitsLayout(t *testing.T) {
+	f := &File{}
+	w := NewBitWriter(f)
+	w.WriteBits(0b1, 1)
+	w.WriteBits(0b0110, 4)
+	w.Flush()
+	w.Order = LSBFirst
+	w.WriteBits(0b1, 1)
+	w.WriteBits(0b0110, 4)
+	w.Flush()
+	if exp, got := "\xb0\x0d", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if _, err := NewBitReader(f).ReadBits(65); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+	}
+}
@@ -0,0 +1,241 @@
+package memio
+
+import (
+	"encoding/binary"
+	"io/fs"
+	"unsafe"
+)
+
+// ReadUint16Slice reads len(dst) 16-bit numbers in the byte order specified by o into dst
+//
+// If o is the host's byte order, the bytes are copied directly into dst, otherwise each number is byte-swapped.
+// If fewer than len(dst) numbers remain, as many as possible are read,
+// and their count is returned with an error wrapping io.ErrUnexpectedEOF.
+func (f *File) ReadUint16Slice(o binary.ByteOrder, dst []uint16) (int, error) {
+	if f.closed {
+		return 0, &MemioError{Op: "File.ReadUint16Slice", Err: fs.ErrClosed}
+	}
+	n := min(len(dst), f.RemainingLen()/2)
+	decodeUint16s(o, dst[:n], f.consume(n*2))
+	if n < len(dst) {
+		return n, &MemioError{Op: "File.ReadUint16Slice", Err: ErrShortBuffer}
+	}
+	return n, nil
+}
+
+// ReadUint32Slice is like ReadUint16Slice, but reads 32-bit numbers
+func (f *File) ReadUint32Slice(o binary.ByteOrder, dst []uint32) (int, error) {
+	if f.closed {
+		return 0, &MemioError{Op: "File.ReadUint32Slice", Err: fs.ErrClosed}
+	}
+	n := min(len(dst), f.RemainingLen()/4)
+	decodeUint32s(o, dst[:n], f.consume(n*4))
+	if n < len(dst) {
+		return n, &MemioError{Op: "File.ReadUint32Slice", Err: ErrShortBuffer}
+	}
+	return n, nil
+}
+
+// ReadUint64Slice is like ReadUint16Slice, but reads 64-bit numbers
+func (f *File) ReadUint64Slice(o binary.ByteOrder, dst []uint64) (int, error) {
+	if f.closed {
+		return 0, &MemioError{Op: "File.ReadUint64Slice", Err: fs.ErrClosed}
+	}
+	n := min(len(dst), f.RemainingLen()/8)
+	decodeUint64s(o, dst[:n], f.consume(n*8))
+	if n < len(dst) {
+		return n, &MemioError{Op: "File.ReadUint64Slice", Err: ErrShortBuffer}
+	}
+	return n, nil
+}
+
+// ReadFloat32Slice is like ReadUint16Slice, but reads IEEE 754 32-bit floats
+func (f *File) ReadFloat32Slice(o binary.ByteOrder, dst []float32) (int, error) {
+	n, err := f.ReadUint32Slice(o, unsafe.Slice((*uint32)(unsafe.Pointer(unsafe.SliceData(dst))), len(dst)))
+	if err != nil {
+		return n, wrapErr("File.ReadFloat32Slice", err)
+	}
+	return n, nil
+}
+
+// ReadFloat64Slice is like ReadUint16Slice, but reads IEEE 754 64-bit floats
+func (f *File) ReadFloat64Slice(o binary.ByteOrder, dst []float64) (int, error) {
+	n, err := f.ReadUint64Slice(o, unsafe.Slice((*uint64)(unsafe.Pointer(unsafe.SliceData(dst))), len(dst)))
+	if err != nil {
+		return n, wrapErr("File.ReadFloat64Slice", err)
+	}
+	return n, nil
+}
+
+// WriteUint16Slice writes the numbers in src in the byte order specified by o
+//
+// If o is the host's byte order, the memory of src is copied directly, otherwise each number is byte-swapped.
+func (f *File) WriteUint16Slice(o binary.ByteOrder, src []uint16) {
+	encodeUint16s(o, f.Expand(len(src)*2), src)
+}
+
+// WriteUint32Slice is like WriteUint16Slice, but writes 32-bit numbers
+func (f *File) WriteUint32Slice(o binary.ByteOrder, src []uint32) {
+	encodeUint32s(o, f.Expand(len(src)*4), src)
+}
+
+// WriteUint64Slice is like WriteUint16Slice, but writes 64-bit numbers
+func (f *File) WriteUint64Slice(o binary.ByteOrder, src []uint64) {
+	encodeUint64s(o, f.Expand(len(src)*8), src)
+}
+
+// WriteFloat32Slice writes the floats in src in IEEE 754 format, in the byte order specified by o
+func (f *File) WriteFloat32Slice(o binary.ByteOrder, src []float32) {
+	f.WriteUint32Slice(o, unsafe.Slice((*uint32)(unsafe.Pointer(unsafe.SliceData(src))), len(src)))
+}
+
+// WriteFloat64Slice writes the floats in src in IEEE 754 format, in the byte order specified by o
+func (f *File) WriteFloat64Slice(o binary.ByteOrder, src []float64) {
+	f.WriteUint64Slice(o, unsafe.Slice((*uint64)(unsafe.Pointer(unsafe.SliceData(src))), len(src)))
+}
+
+// asBytes returns the memory of s as a byte slice
+func asBytes[T uint16 | uint32 | uint64](s []T) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(s))), len(s)*int(unsafe.Sizeof(T(0))))
+}
+
+// decodeUint16s decodes len(dst) numbers from s
+//
+// If o is the host's byte order, s is copied directly into dst's memory.
+// Otherwise, the common byte orders are special-cased to avoid calling o for each number.
+func decodeUint16s(o binary.ByteOrder, dst []uint16, s []byte) {
+	s = s[:len(dst)*2]
+	if isNativeOrder(o) {
+		copy(asBytes(dst), s)
+		return
+	}
+	switch o {
+	case binary.LittleEndian:
+		for i := range dst {
+			dst[i] = binary.LittleEndian.Uint16(s[i*2:])
+		}
+	case binary.BigEndian:
+		for i := range dst {
+			dst[i] = binary.BigEndian.Uint16(s[i*2:])
+		}
+	default:
+		for i := range dst {
+			dst[i] = o.Uint16(s[i*2:])
+		}
+	}
+}
+
+// decodeUint32s decodes len(dst) numbers from s, like decodeUint16s
+func decodeUint32s(o binary.ByteOrder, dst []uint32, s []byte) {
+	s = s[:len(dst)*4]
+	if isNativeOrder(o) {
+		copy(asBytes(dst), s)
+		return
+	}
+	switch o {
+	case binary.LittleEndian:
+		for i := range dst {
+			dst[i] = binary.LittleEndian.Uint32(s[i*4:])
+		}
+	case binary.BigEndian:
+		for i := range dst {
+			dst[i] = binary.BigEndian.Uint32(s[i*4:])
+		}
+	default:
+		for i := range dst {
+			dst[i] = o.Uint32(s[i*4:])
+		}
+	}
+}
+
+// decodeUint64s decodes len(dst) numbers from s, like decodeUint16s
+func decodeUint64s(o binary.ByteOrder, dst []uint64, s []byte) {
+	s = s[:len(dst)*8]
+	if isNativeOrder(o) {
+		copy(asBytes(dst), s)
+		return
+	}
+	switch o {
+	case binary.LittleEndian:
+		for i := range dst {
+			dst[i] = binary.LittleEndian.Uint64(s[i*8:])
+		}
+	case binary.BigEndian:
+		for i := range dst {
+			dst[i] = binary.BigEndian.Uint64(s[i*8:])
+		}
+	default:
+		for i := range dst {
+			dst[i] = o.Uint64(s[i*8:])
+		}
+	}
+}
+
+// encodeUint16s encodes the numbers in src into s, like decodeUint16s
+func encodeUint16s(o binary.ByteOrder, s []byte, src []uint16) {
+	s = s[:len(src)*2]
+	if isNativeOrder(o) {
+		copy(s, asBytes(src))
+		return
+	}
+	switch o {
+	case binary.LittleEndian:
+		for i, v := range src {
+			binary.LittleEndian.PutUint16(s[i*2:], v)
+		}
+	case binary.BigEndian:
+		for i, v := range src {
+			binary.BigEndian.PutUint16(s[i*2:], v)
+		}
+	default:
+		for i, v := range src {
+			o.PutUint16(s[i*2:], v)
+		}
+	}
+}
+
+// encodeUint32s encodes the numbers in src into s, like decodeUint16s
+func encodeUint32s(o binary.ByteOrder, s []byte, src []uint32) {
+	s = s[:len(src)*4]
+	if isNativeOrder(o) {
+		copy(s, asBytes(src))
+		return
+	}
+	switch o {
+	case binary.LittleEndian:
+		for i, v := range src {
+			binary.LittleEndian.PutUint32(s[i*4:], v)
+		}
+	case binary.BigEndian:
+		for i, v := range src {
+			binary.BigEndian.PutUint32(s[i*4:], v)
+		}
+	default:
+		for i, v := range src {
+			o.PutUint32(s[i*4:], v)
+		}
+	}
+}
+
+// encodeUint64s encodes the numbers in src into s, like decodeUint16s
+func encodeUint64s(o binary.ByteOrder, s []byte, src []uint64) {
+	s = s[:len(src)*8]
+	if isNativeOrder(o) {
+		copy(s, asBytes(src))
+		return
+	}
+	switch o {
+	case binary.LittleEndian:
+		for i, v := range src {
+			binary.LittleEndian.PutUint64(s[i*8:], v)
+		}
+	case binary.BigEndian:
+		for i, v := range src {
+			binary.BigEndian.PutUint64(s[i*8:], v)
+		}
+	default:
+		for i, v := range src {
+			o.PutUint64(s[i*8:], v)
+		}
+	}
+}
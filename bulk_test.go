@@ -0,0 +1,116 @@
+package memio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestBulk(t *testing.T) {
+	u16 := []uint16{3, 0xbeef}
+	u32 := []uint32{1, 0xdeadbeef, math.MaxUint32}
+	u64 := []uint64{2, 0xdeadbeefcafebabe}
+	f32 := []float32{1.5, float32(math.Inf(-1))}
+	f64 := []float64{-2.25, math.Pi}
+	for _, o := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian, binary.NativeEndian} {
+		f := &File{}
+		f.WriteUint16Slice(o, u16)
+		f.WriteUint32Slice(o, u32)
+		f.WriteUint64Slice(o, u64)
+		f.WriteFloat32Slice(o, f32)
+		f.WriteFloat64Slice(o, f64)
+
+		exp := &File{}
+		for _, v := range u16 {
+			exp.WriteUint16(o, v)
+		}
+		for _, v := range u32 {
+			exp.WriteUint32(o, v)
+		}
+		for _, v := range u64 {
+			exp.WriteUint64(o, v)
+		}
+		for _, v := range f32 {
+			exp.WriteFloat32(o, v)
+		}
+		for _, v := range f64 {
+			exp.WriteFloat64(o, v)
+		}
+		if !f.Equal(exp) {
+			t.Fatalf("%v: Expected %q; Got %q", o, exp.StringRef(), f.StringRef())
+		}
+
+		f.Rewind()
+		gu16, gu32, gu64 := make([]uint16, len(u16)), make([]uint32, len(u32)), make([]uint64, len(u64))
+		gf32, gf64 := make([]float32, len(f32)), make([]float64, len(f64))
+		f.ReadUint16Slice(o, gu16)
+		f.ReadUint32Slice(o, gu32)
+		f.ReadUint64Slice(o, gu64)
+		f.ReadFloat32Slice(o, gf32)
+		if n, err := f.ReadFloat64Slice(o, gf64); err != nil || n != len(f64) {
+			t.Fatalf("%v: Expected (%d, nil); Got (%d, %v)", o, len(f64), n, err)
+		}
+		if !slices.Equal(gu16, u16) || !slices.Equal(gu32, u32) || !slices.Equal(gu64, u64) || !slices.Equal(gf32, f32) || !slices.Equal(gf64, f64) {
+			t.Fatalf("%v: Expected %v %v %v %v %v; Got %v %v %v %v %v", o, u16, u32, u64, f32, f64, gu16, gu32, gu64, gf32, gf64)
+		}
+	}
+
+	f := NewFile([]byte("\x01\x00\x00\x00\x02\x00\x00\x00\x03\x00"))
+	dst := make([]uint32, 4)
+	n, err := f.ReadUint32Slice(binary.LittleEndian, dst)
+	if n != 2 || !errors.Is(err, io.ErrUnexpectedEOF) || !slices.Equal(dst, []uint32{1, 2, 0, 0}) {
+		t.Fatalf("Expected (2, io.ErrUnexpectedEOF, [1 2 0 0]); Got (%d, %v, %v)", n, err, dst)
+	}
+	if exp, got := 2, f.RemainingLen(); got != exp {
+		t.Fatalf("Expected %d remaining; Got %d", exp, got)
+	}
+
+	n, err = f.ReadFloat32Slice(binary.LittleEndian, make([]float32, 1))
+	if exp, got := "File.ReadFloat32Slice: "+ErrShortBuffer.Error(), fmt.Sprint(err); n != 0 || got != exp {
+		t.Fatalf("Expected (0, %q); Got (%d, %q)", exp, n, got)
+	}
+}
+
+func BenchmarkReadUint32Slice(b *testing.B) {
+	f := NewFile(make([]byte, 4<<10))
+	dst := make([]uint32, f.Len()/4)
+	b.Run("ReadUint32Slice", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(f.Len()))
+		for i := 0; i < b.N; i++ {
+			f.Rewind().ReadUint32Slice(binary.LittleEndian, dst)
+		}
+	})
+	b.Run("ReadUint32", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(f.Len()))
+		for i := 0; i < b.N; i++ {
+			f.Rewind()
+			for j := range dst {
+				dst[j], _ = f.ReadUint32(binary.LittleEndian)
+			}
+		}
+	})
+}
+
+func BenchmarkReadUint16Slice(b *testing.B) {
+	f := NewFile(make([]byte, 4<<10))
+	dst := make([]uint16, f.Len()/2)
+	swapped := binary.ByteOrder(binary.BigEndian)
+	if nativeBigEndian {
+		swapped = binary.LittleEndian
+	}
+	for name, o := range map[string]binary.ByteOrder{"Native": binary.NativeEndian, "Swapped": swapped} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(f.Len()))
+			for i := 0; i < b.N; i++ {
+				f.Rewind().ReadUint16Slice(o, dst)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package memio
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+var (
+	_ json.Marshaler   = (*File)(nil)
+	_ json.Unmarshaler = (*File)(nil)
+)
+
+// MarshalJSON implements json.Marshaler
+//
+// The internal buffer is encoded as a base64 string, like []byte. The current position is not encoded.
+func (f *File) MarshalJSON() ([]byte, error) {
+	p, err := json.Marshal(f.buf)
+	if err != nil {
+		return nil, &MemioError{Op: "File.MarshalJSON", Err: err}
+	}
+	return p, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+//
+// It decodes the format produced by MarshalJSON, and sets the current position to 0.
+func (f *File) UnmarshalJSON(data []byte) error {
+	if f.closed {
+		return &MemioError{Op: "File.UnmarshalJSON", Err: fs.ErrClosed}
+	}
+	if f.readOnly {
+		return &MemioError{Op: "File.UnmarshalJSON", Err: fs.ErrPermission}
+	}
+	p := []byte(nil)
+	if err := json.Unmarshal(data, &p); err != nil {
+		return &MemioError{Op: "File.UnmarshalJSON", Err: err}
+	}
+	f.ResetBytes(p)
+	return nil
+}
+
+// PrintHex writes the lowercase hex encoding of p
+func (f *File) PrintHex(p []byte) *File {
+	if s := f.printExpand(hex.EncodedLen(len(p))); s != nil {
+		hex.Encode(s, p)
+	}
+	return f
+}
+
+// ReadHexN reads 2*n hex characters and returns the n decoded bytes
+//
+// An error wrapping io.ErrUnexpectedEOF is returned if fewer than 2*n characters remain,
+// or an error wrapping the hex error if the characters are not valid hex.
+// An error wrapping fs.ErrInvalid is returned if n is negative.
+func (f *File) ReadHexN(n int) ([]byte, error) {
+	if f.closed {
+		return nil, &MemioError{Op: "File.ReadHexN", Err: fs.ErrClosed}
+	}
+	if n < 0 {
+		return nil, &MemioError{Op: "File.ReadHexN", Err: fmt.Errorf("negative count(%d): %w", n, fs.ErrInvalid)}
+	}
+	s, err := f.ReadN(hex.EncodedLen(n))
+	if err != nil {
+		return nil, wrapErr("File.ReadHexN", err)
+	}
+	p := make([]byte, n)
+	if _, err := hex.Decode(p, s); err != nil {
+		return nil, &MemioError{Op: "File.ReadHexN", Err: err}
+	}
+	return p, nil
+}
+
+// PrintBase64 writes the encoding of p using enc
+func (f *File) PrintBase64(p []byte, enc *base64.Encoding) *File {
+	if s := f.printExpand(enc.EncodedLen(len(p))); s != nil {
+		enc.Encode(s, p)
+	}
+	return f
+}
+
+// ReadBase64 reads the bytes from the current position to the end and returns them decoded using enc
+func (f *File) ReadBase64(enc *base64.Encoding) ([]byte, error) {
+	if f.closed {
+		return nil, &MemioError{Op: "File.ReadBase64", Err: fs.ErrClosed}
+	}
+	s := f.consume(len(f.buf) - f.pos)
+	p, err := decodeBase64(enc, s)
+	if err != nil {
+		return nil, &MemioError{Op: "File.ReadBase64", Err: err}
+	}
+	return p, nil
+}
+
+// ReadBase64Delim reads bytes up to and excluding delim and returns them decoded using enc
+//
+// If delim is not found, the bytes up to the end are decoded.
+func (f *File) ReadBase64Delim(enc *base64.Encoding, delim byte) ([]byte, error) {
+	if f.closed {
+		return nil, &MemioError{Op: "File.ReadBase64Delim", Err: fs.ErrClosed}
+	}
+	s, _ := f.readBytes(delim)
+	p, err := decodeBase64(enc, s)
+	if err != nil {
+		return nil, &MemioError{Op: "File.ReadBase64Delim", Err: err}
+	}
+	return p, nil
+}
+
+// decodeBase64 returns the decoding of s using enc
+func decodeBase64(enc *base64.Encoding, s []byte) ([]byte, error) {
+	p := make([]byte, enc.DecodedLen(len(s)))
+	n, err := enc.Decode(p, s)
+	return p[:n], err
+}
+
+// HexDump returns a dump of the internal buffer in the format of `hexdump -C`, like hex.Dump
+//
+// It doesn't change the current position.
+func (f *File) HexDump() string {
+	return hexDump(f.buf, 0)
+}
+
+// HexDumpRange is like HexDump, but dumps the bytes in range [start:end], labelled with their offsets in the internal buffer
+//
+// An error wrapping fs.ErrInvalid is returned if the range is reversed or out of bounds.
+func (f *File) HexDumpRange(start, end int64) (string, error) {
+	if start < 0 || start > end || end > int64(len(f.buf)) {
+		return "", &MemioError{Op: "File.HexDumpRange", Err: fmt.Errorf("range [%d:%d] out of bounds: %w", start, end, fs.ErrInvalid)}
+	}
+	return hexDump(f.buf[start:end], start), nil
+}
+
+// hexDump implements HexDump and HexDumpRange, labelling each line of 16 bytes with its offset from off
+func hexDump(p []byte, off int64) string {
+	const digits = "0123456789abcdef"
+	sb := strings.Builder{}
+	sb.Grow((len(p) + 15) / 16 * 79)
+	for len(p) > 0 {
+		line := p[:min(len(p), 16)]
+		p = p[len(line):]
+		fmt.Fprintf(&sb, "%08x  ", off)
+		off += int64(len(line))
+		for i := range 16 {
+			if i < len(line) {
+				sb.WriteByte(digits[line[i]>>4])
+				sb.WriteByte(digits[line[i]&0xf])
+				sb.WriteByte(' ')
+			} else {
+				sb.WriteString("   ")
+			}
+			if i == 7 {
+				sb.WriteByte(' ')
+			}
+		}
+		sb.WriteString(" |")
+		for _, c := range line {
+			if c < 32 || c > 126 {
+				c = '.'
+			}
+			sb.WriteByte(c)
+		}
+		sb.WriteString("|\n")
+	}
+	return sb.String()
+}
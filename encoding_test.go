@@ -0,0 +1,108 @@
+package memio
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+)
+
+func TestHex(t *testing.T) {
+	f := (&File{}).PrintHex([]byte{0xde, 0xad, 0xbe, 0xef}).PrintHex([]byte("hi"))
+	if exp, got := "deadbeef6869", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	f.Rewind()
+	if p, err := f.ReadHexN(4); err != nil || string(p) != "\xde\xad\xbe\xef" {
+		t.Fatalf("Expected (%q, nil); Got (%q, %v)", "\xde\xad\xbe\xef", p, err)
+	}
+	if _, err := f.ReadHexN(3); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected io.ErrUnexpectedEOF; Got %v", err)
+	}
+	if _, err := f.ReadHexN(-1); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+	}
+
+	f = NewFile([]byte("zz"))
+	if _, err := f.ReadHexN(1); !errors.As(err, new(hex.InvalidByteError)) {
+		t.Fatalf("Expected hex.InvalidByteError; Got %v", err)
+	}
+}
+
+func TestBase64(t *testing.T) {
+	f := (&File{}).PrintBase64([]byte("hello"), base64.StdEncoding)
+	f.WriteByte('\n')
+	f.PrintBase64([]byte("world"), base64.RawURLEncoding)
+	if exp, got := "aGVsbG8=\nd29ybGQ", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	f.Rewind()
+	if p, err := f.ReadBase64Delim(base64.StdEncoding, '\n'); err != nil || string(p) != "hello" {
+		t.Fatalf("Expected (%q, nil); Got (%q, %v)", "hello", p, err)
+	}
+	if p, err := f.ReadBase64(base64.RawURLEncoding); err != nil || string(p) != "world" {
+		t.Fatalf("Expected (%q, nil); Got (%q, %v)", "world", p, err)
+	}
+
+	f = NewFile([]byte("!!!!"))
+	if _, err := f.ReadBase64(base64.StdEncoding); !errors.As(err, new(base64.CorruptInputError)) {
+		t.Fatalf("Expected base64.CorruptInputError; Got %v", err)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type config struct {
+		Blob *File
+	}
+	src := config{Blob: NewFile([]byte("hello"))}
+	src.Blob.Seek(2, io.SeekStart)
+	p, err := json.Marshal(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp, got := `{"Blob":"aGVsbG8="}`, string(p); got != exp {
+		t.Fatalf("Expected %s; Got %s", exp, got)
+	}
+
+	dst := config{}
+	if err := json.Unmarshal(p, &dst); err != nil {
+		t.Fatal(err)
+	}
+	if !dst.Blob.Equal(src.Blob) || dst.Blob.Offset() != 0 {
+		t.Fatalf("Expected (%q, 0); Got (%q, %d)", "hello", dst.Blob.StringRef(), dst.Blob.Offset())
+	}
+}
+
+func TestHexDump(t *testing.T) {
+	for _, n := range []int{0, 1, 8, 15, 16, 17, 40} {
+		p := make([]byte, n)
+		for i := range p {
+			p[i] = byte(i*7 + 30)
+		}
+		f := NewFile(p)
+		if exp, got := hex.Dump(p), f.HexDump(); got != exp {
+			t.Fatalf("n=%d: Expected\n%s\nGot\n%s", n, exp, got)
+		}
+	}
+
+	f := NewFile([]byte("0123456789abcdefghijklmnopqrstuvwxyz\x00\xff"))
+	f.ReadByte()
+	got, err := f.HexDumpRange(18, 38)
+	exp := "" +
+		"00000012  69 6a 6b 6c 6d 6e 6f 70  71 72 73 74 75 76 77 78  |ijklmnopqrstuvwx|\n" +
+		"00000022  79 7a 00 ff                                       |yz..|\n"
+	if err != nil || got != exp {
+		t.Fatalf("Expected\n%s\nGot\n%s%v", exp, got, err)
+	}
+	if exp, got := int64(1), f.Offset(); got != exp {
+		t.Fatalf("Expected offset %d; Got %d", exp, got)
+	}
+	if _, err := f.HexDumpRange(5, 39); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package memio
+
+import (
+	"encoding/binary"
+)
+
+// The methods in this file are equivalent to the ByteOrder-taking methods with binary.LittleEndian or binary.BigEndian,
+// but call the concrete byte order directly, avoiding the interface call in tight loops.
+
+// ReadUint16LE reads a little-endian 16-bit number
+func (f *File) ReadUint16LE() (uint16, error) {
+	p := [2]byte{}
+	if _, err := f.readFull(p[:]); err != nil {
+		return 0, &MemioError{Op: "File.ReadUint16LE", Err: err}
+	}
+	return binary.LittleEndian.Uint16(p[:]), nil
+}
+
+// ReadUint16BE reads a big-endian 16-bit number
+func (f *File) ReadUint16BE() (uint16, error) {
+	p := [2]byte{}
+	if _, err := f.readFull(p[:]); err != nil {
+		return 0, &MemioError{Op: "File.ReadUint16BE", Err: err}
+	}
+	return binary.BigEndian.Uint16(p[:]), nil
+}
+
+// ReadUint32LE reads a little-endian 32-bit number
+func (f *File) ReadUint32LE() (uint32, error) {
+	p := [4]byte{}
+	if _, err := f.readFull(p[:]); err != nil {
+		return 0, &MemioError{Op: "File.ReadUint32LE", Err: err}
+	}
+	return binary.LittleEndian.Uint32(p[:]), nil
+}
+
+// ReadUint32BE reads a big-endian 32-bit number
+func (f *File) ReadUint32BE() (uint32, error) {
+	p := [4]byte{}
+	if _, err := f.readFull(p[:]); err != nil {
+		return 0, &MemioError{Op: "File.ReadUint32BE", Err: err}
+	}
+	return binary.BigEndian.Uint32(p[:]), nil
+}
+
+// ReadUint64LE reads a little-endian 64-bit number
+func (f *File) ReadUint64LE() (uint64, error) {
+	p := [8]byte{}
+	if _, err := f.readFull(p[:]); err != nil {
+		return 0, &MemioError{Op: "File.ReadUint64LE", Err: err}
+	}
+	return binary.LittleEndian.Uint64(p[:]), nil
+}
+
+// ReadUint64BE reads a big-endian 64-bit number
+func (f *File) ReadUint64BE() (uint64, error) {
+	p := [8]byte{}
+	if _, err := f.readFull(p[:]); err != nil {
+		return 0, &MemioError{Op: "File.ReadUint64BE", Err: err}
+	}
+	return binary.BigEndian.Uint64(p[:]), nil
+}
+
+// WriteUint16LE writes n as a little-endian 16-bit number
+func (f *File) WriteUint16LE(n uint16) {
+	binary.LittleEndian.PutUint16(f.Expand(2), n)
+}
+
+// WriteUint16BE writes n as a big-endian 16-bit number
+func (f *File) WriteUint16BE(n uint16) {
+	binary.BigEndian.PutUint16(f.Expand(2), n)
+}
+
+// WriteUint32LE writes n as a little-endian 32-bit number
+func (f *File) WriteUint32LE(n uint32) {
+	binary.LittleEndian.PutUint32(f.Expand(4), n)
+}
+
+// WriteUint32BE writes n as a big-endian 32-bit number
+func (f *File) WriteUint32BE(n uint32) {
+	binary.BigEndian.PutUint32(f.Expand(4), n)
+}
+
+// WriteUint64LE writes n as a little-endian 64-bit number
+func (f *File) WriteUint64LE(n uint64) {
+	binary.LittleEndian.PutUint64(f.Expand(8), n)
+}
+
+// WriteUint64BE writes n as a big-endian 64-bit number
+func (f *File) WriteUint64BE(n uint64) {
+	binary.BigEndian.PutUint64(f.Expand(8), n)
+}
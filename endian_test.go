@@ -0,0 +1,67 @@
+package memio
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestEndian(t *testing.T) {
+	f := &File{}
+	f.WriteUint16LE(0x0102)
+	f.WriteUint16BE(0x0102)
+	f.WriteUint32LE(0x01020304)
+	f.WriteUint32BE(0x01020304)
+	f.WriteUint64LE(0x0102030405060708)
+	f.WriteUint64BE(0x0102030405060708)
+	exp := &File{}
+	exp.WriteUint16(binary.LittleEndian, 0x0102)
+	exp.WriteUint16(binary.BigEndian, 0x0102)
+	exp.WriteUint32(binary.LittleEndian, 0x01020304)
+	exp.WriteUint32(binary.BigEndian, 0x01020304)
+	exp.WriteUint64(binary.LittleEndian, 0x0102030405060708)
+	exp.WriteUint64(binary.BigEndian, 0x0102030405060708)
+	if !f.Equal(exp) {
+		t.Fatalf("Expected %q; Got %q", exp.StringRef(), f.StringRef())
+	}
+
+	f.Rewind()
+	a, _ := f.ReadUint16LE()
+	b, _ := f.ReadUint16BE()
+	c, _ := f.ReadUint32LE()
+	d, _ := f.ReadUint32BE()
+	e, _ := f.ReadUint64LE()
+	g, err := f.ReadUint64BE()
+	if err != nil || a != 0x0102 || b != 0x0102 || c != 0x01020304 || d != 0x01020304 || e != 0x0102030405060708 || g != 0x0102030405060708 {
+		t.Fatalf("Unexpected values %#x %#x %#x %#x %#x %#x, %v", a, b, c, d, e, g, err)
+	}
+	if _, err := f.ReadUint32LE(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected io.ErrUnexpectedEOF; Got %v", err)
+	}
+}
+
+func BenchmarkReadUint32(b *testing.B) {
+	f := NewFile(make([]byte, 4<<10))
+	b.Run("ReadUint32LE", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(f.Len()))
+		for i := 0; i < b.N; i++ {
+			f.Rewind()
+			for f.RemainingLen() != 0 {
+				f.ReadUint32LE()
+			}
+		}
+	})
+	b.Run("ReadUint32", func(b *testing.B) {
+		var o binary.ByteOrder = binary.LittleEndian
+		b.ReportAllocs()
+		b.SetBytes(int64(f.Len()))
+		for i := 0; i < b.N; i++ {
+			f.Rewind()
+			for f.RemainingLen() != 0 {
+				f.ReadUint32(o)
+			}
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package memio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+)
+
+var (
+	// ErrShortBuffer is returned when fewer bytes remain than a read requires
+	//
+	// It wraps io.ErrUnexpectedEOF.
+	ErrShortBuffer = fmt.Errorf("memio: short buffer: %w", io.ErrUnexpectedEOF)
+
+	// ErrLimitExceeded is returned when a read exceeds its size limit
+	ErrLimitExceeded = errors.New("memio: limit exceeded")
+
+	// ErrMaxSizeExceeded is returned when a write would grow a File beyond the size set by SetMaxSize
+	ErrMaxSizeExceeded = errors.New("memio: max size exceeded")
+)
+
+// MemioError records an error and the operation that caused it
+//
+// All errors returned by File methods are of this type,
+// except where documented otherwise to satisfy an interface contract (e.g. io.EOF from Read).
+type MemioError struct {
+	// Op is the operation that failed, e.g. "File.Seek"
+	Op string
+	// Err is the underlying error
+	Err error
+}
+
+// Error implements the error interface
+func (e *MemioError) Error() string {
+	return e.Op + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *MemioError) Unwrap() error {
+	return e.Err
+}
+
+// wrapErr returns err as a *MemioError for op
+//
+// If err is already a *MemioError, its Op is replaced instead of nesting it, so the message names each operation once.
+func wrapErr(op string, err error) error {
+	if e, ok := err.(*MemioError); ok {
+		return &MemioError{Op: op, Err: e.Err}
+	}
+	return &MemioError{Op: op, Err: err}
+}
+
+// Try calls fn, and returns the error that a File method called by fn panicked with because the File couldn't be used
+//
+// Methods that write without returning an error, like WriteUint32, WriteVarString, BitWriter.WriteBits and OrderedFile.WriteU32,
+// panic with an error wrapping fs.ErrPermission, fs.ErrClosed or ErrMaxSizeExceeded
+// if the File is read-only, closed or would exceed its max size. Try returns that error instead.
+// Bytes written by fn before the failing write are kept. Other panics are propagated.
+func Try(fn func()) (err error) {
+	defer func() {
+		v := recover()
+		if v == nil {
+			return
+		}
+		e, ok := v.(*MemioError)
+		if !ok || !(errors.Is(e, fs.ErrPermission) || errors.Is(e, fs.ErrClosed) || errors.Is(e, ErrMaxSizeExceeded)) {
+			panic(v)
+		}
+		err = e
+	}()
+	fn()
+	return nil
+}
@@ -0,0 +1,53 @@
+package memio
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+)
+
+func TestMemioError(t *testing.T) {
+	f := NewFile([]byte("a"))
+	_, err := f.ReadUint16(binary.BigEndian)
+	me := (*MemioError)(nil)
+	if !errors.As(err, &me) || me.Op != "File.ReadUint16" {
+		t.Fatalf("Expected *MemioError with Op File.ReadUint16; Got %#v", err)
+	}
+	if !errors.Is(err, ErrShortBuffer) || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected error wrapping ErrShortBuffer and io.ErrUnexpectedEOF; Got %v", err)
+	}
+
+	_, err = f.Seek(0, 42)
+	if !errors.As(err, &me) || me.Op != "File.Seek" || !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected *MemioError with Op File.Seek wrapping fs.ErrInvalid; Got %#v", err)
+	}
+	if exp, got := "File.Seek: invalid whence(42): invalid argument", err.Error(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
+
+func TestTry(t *testing.T) {
+	f := NewFile(nil).SetMaxSize(6)
+	err := Try(func() {
+		f.WriteUint32(binary.BigEndian, 1)
+		f.WriteUint32(binary.BigEndian, 2)
+	})
+	if !errors.Is(err, ErrMaxSizeExceeded) {
+		t.Fatalf("Expected error wrapping ErrMaxSizeExceeded; Got %v", err)
+	}
+	if exp, got := "\x00\x00\x00\x01", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if err := Try(func() { f.WriteUint8(1) }); err != nil {
+		t.Fatalf("Expected nil; Got %v", err)
+	}
+
+	defer func() {
+		if v := recover(); v != "memio: Expand: negative count" {
+			t.Fatalf("Expected other panics to be propagated; Got %v", v)
+		}
+	}()
+	Try(func() { f.Expand(-1) })
+}
@@ -5,263 +5,1688 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"io/fs"
+	"iter"
 	"math"
 	"slices"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 	"unsafe"
 )
 
+const (
+	// minReadChunk is the minimum free capacity ReadFrom reads into
+	minReadChunk = 1 << 10
+	// maxReadChunk is the maximum amount ReadFrom grows the internal buffer by at once
+	maxReadChunk = 1 << 20
+	// maxSeekLen is the maximum length Seek will expand the internal buffer to
+	maxSeekLen = math.MaxInt32
+)
+
+var (
+	_ io.Reader       = (*File)(nil)
+	_ io.Writer       = (*File)(nil)
+	_ io.Seeker       = (*File)(nil)
+	_ io.ReaderFrom   = (*File)(nil)
+	_ io.WriterTo     = (*File)(nil)
+	_ io.ByteReader   = (*File)(nil)
+	_ io.ByteScanner  = (*File)(nil)
+	_ io.ByteWriter   = (*File)(nil)
+	_ io.StringWriter = (*File)(nil)
+	_ io.WriterAt     = (*File)(nil)
+	_ fs.File         = (*File)(nil)
+	_ fs.ReadDirFile  = (*File)(nil)
+	_ fs.FileInfo     = (*File)(nil)
+)
+
 // File implements file-like methods on an in-memory buffer
 type File struct {
-	pos int
-	buf []byte
+	pos        int
+	buf        []byte
+	appendMode bool
+	nread      int64
+	nwritten   int64
+	// unreadPos is the position after the last ReadByte, or 0 if UnreadByte is not allowed
+	unreadPos int
+	// holes are the sorted ranges of the internal buffer that were zero-filled without being written
+	holes []span
+	// strictClose makes Close invalidate the File, see SetStrictClose
+	strictClose bool
+	closed      bool
+	// readTee receives a copy of all bytes consumed by reads, see SetReadTee
+	readTee    io.Writer
+	readTeeErr error
+	// shared is true if the internal buffer may be shared with another File by Fork
+	shared bool
+	// borrowed is true if the internal buffer may be referenced outside the File, e.g. it was passed to NewFile, so Put must not retain it
+	borrowed bool
+	// readOnly makes writes fail, see SetReadOnly
+	readOnly bool
+	// crcTable enables crc, the checksum of the bytes added before crcPos, see EnableChecksum
+	crcTable *crc32.Table
+	crc      uint32
+	crcPos   int
+	// mode and modTime are returned by Mode and ModTime, see SetMode and SetModTime
+	mode    fs.FileMode
+	hasMode bool
+	modTime time.Time
+	// maxSize limits the length of the internal buffer if it's positive, see SetMaxSize
+	maxSize int
 }
 
 // Len returns the length of the internal buffer
+//
+// It's always true that 0 <= Offset() <= Len() <= Cap(), and Available() == Cap() - Len().
 func (f *File) Len() int {
 	return len(f.buf)
 }
 
+// Cap returns the capacity of the internal buffer
+//
+// Writes that don't extend the internal buffer beyond Cap() don't reallocate.
+func (f *File) Cap() int {
+	return cap(f.buf)
+}
+
+// Remaining returns the bytes from the current position to the end of the internal buffer
+//
+// Unlike WriteTo, it doesn't change the current position.
+// The slice is a reference to the internal buffer, so it's invalidated by subsequent writes.
+func (f *File) Remaining() []byte {
+	return f.buf[f.pos:]
+}
+
+// RemainingLen returns the number of bytes from the current position to the end of the internal buffer
+func (f *File) RemainingLen() int {
+	return len(f.buf) - f.pos
+}
+
+// Available returns the number of bytes of unused capacity after the end of the internal buffer
+//
+// It's equivalent to Cap() - Len().
+func (f *File) Available() int {
+	return cap(f.buf) - len(f.buf)
+}
+
+// AvailableBuffer returns an empty slice with the unused capacity after the end of the internal buffer, like bytes.Buffer.AvailableBuffer
+//
+// It's intended to be appended to and passed to an immediately succeeding Write call.
+// If the current position is at the end of the internal buffer, the Write doesn't reallocate.
+// The slice is only valid until the next write.
+func (f *File) AvailableBuffer() []byte {
+	if f.readOnly || f.closed {
+		return f.buf[len(f.buf):len(f.buf):len(f.buf)]
+	}
+	f.own("File.AvailableBuffer")
+	return f.buf[len(f.buf):]
+}
+
 // Offset returns the current read/write position of the internal buffer
+//
+// It's at most Len(), so RemainingLen() == Len() - Offset().
 func (f *File) Offset() int64 {
 	return int64(f.pos)
 }
 
+// IndexByte returns the index of the first b in the remaining bytes, or -1 if it's not present
+//
+// The index is relative to the current position, so Seek(int64(i), io.SeekCurrent) moves to it.
+// It doesn't change the current position.
+func (f *File) IndexByte(b byte) int {
+	return bytes.IndexByte(f.buf[f.pos:], b)
+}
+
+// Contains reports whether p is within the remaining bytes
+//
+// It doesn't change the current position.
+func (f *File) Contains(p []byte) bool {
+	return bytes.Contains(f.buf[f.pos:], p)
+}
+
+// Count returns the number of b in the remaining bytes
+//
+// It doesn't change the current position.
+func (f *File) Count(b byte) int {
+	return bytes.Count(f.buf[f.pos:], []byte{b})
+}
+
 // Bytes returns the internal buffer
+//
+// The slice aliases the internal buffer: modifying it modifies the File,
+// and subsequent writes to the File may modify it, or may not if they reallocate.
+// Use BytesCopy if the slice outlives the next write, e.g. if it's passed to another goroutine.
 func (f *File) Bytes() []byte {
 	return f.buf
 }
 
+// BytesCopy returns a copy of the internal buffer
+//
+// Unlike Bytes, the result is not affected by subsequent writes.
+func (f *File) BytesCopy() []byte {
+	return append([]byte(nil), f.buf...)
+}
+
+// Equal reports whether f and other have the same content, ignoring their positions
+//
+// A nil File is equal to an empty File.
+func (f *File) Equal(other *File) bool {
+	return bytes.Equal(f.content(), other.content())
+}
+
+// EqualBytes reports whether the content of f is equal to p, ignoring its position
+func (f *File) EqualBytes(p []byte) bool {
+	return bytes.Equal(f.content(), p)
+}
+
+// Compare compares the content of f and other lexicographically, like bytes.Compare
+//
+// A nil File is equal to an empty File.
+func (f *File) Compare(other *File) int {
+	return bytes.Compare(f.content(), other.content())
+}
+
+// content returns the internal buffer, or nil if f is nil
+func (f *File) content() []byte {
+	if f == nil {
+		return nil
+	}
+	return f.buf
+}
+
 // String returns a copy of the internal buffer as a string
 func (f *File) String() string {
 	return string(f.buf)
 }
 
 // StringRef returns a reference to the internal buffer as a string
+//
+// It returns "" if the internal buffer is empty or nil, without referencing it.
 func (f *File) StringRef() string {
+	if len(f.buf) == 0 {
+		return ""
+	}
 	return unsafe.String(unsafe.SliceData(f.buf), len(f.buf))
 }
 
+// TakeString returns the internal buffer as a string without copying it, and empties the File like Clear
+//
+// Since the File no longer references the memory, it can't modify the string.
+// Slices previously returned by Bytes, etc. must not be modified afterwards.
+// If the File is read-only, the memory isn't the File's to give away, so it's copied.
+func (f *File) TakeString() string {
+	s := f.StringRef()
+	if f.readOnly {
+		s = strings.Clone(s)
+	}
+	f.Clear()
+	return s
+}
+
+// TakeBytes returns the internal buffer, and empties the File like Clear
+//
+// The caller takes ownership of the slice; it's copied only if it's shared with another File by Fork,
+// or the File is read-only, e.g. after ResetString, so it may not be modified.
+func (f *File) TakeBytes() []byte {
+	p := f.buf
+	if f.shared || f.readOnly {
+		p = slices.Clone(p)
+	}
+	f.Clear()
+	return p
+}
+
 // Reset is equivalent to Truncate(0)
+//
+// The capacity of the internal buffer is retained for reuse, use Clear to release it.
 func (f *File) Reset() *File {
 	return f.Truncate(0)
 }
 
-// Truncate sets the internal offset and buffer size to n
+// Clear releases the internal buffer and sets the internal offset to 0
+//
+// Unlike Reset, the memory used by the internal buffer can be reclaimed by the garbage collector.
+func (f *File) Clear() *File {
+	f.mustBeOpen("File.Clear")
+	f.updateChecksum()
+	f.crcPos = 0
+	f.buf = nil
+	f.pos = 0
+	f.holes = nil
+	f.shared = false
+	f.borrowed = false
+	return f
+}
+
+// ResetBytes sets the internal buffer to s and the internal offset to 0, and disables read-only mode
+//
+// It allows a single File to be reused across many inputs without allocating.
+func (f *File) ResetBytes(s []byte) *File {
+	f.mustBeOpen("File.ResetBytes")
+	f.updateChecksum()
+	f.crcPos = len(s)
+	f.buf = s
+	f.pos = 0
+	f.holes = nil
+	f.shared = false
+	f.borrowed = s != nil
+	f.readOnly = false
+	return f
+}
+
+// ResetString sets the internal buffer to a reference to s and the internal offset to 0, and enables read-only mode
+//
+// Strings are immutable, so the File stays read-only until it's reset again with ResetBytes, or SetReadOnly(false) is called;
+// writing to it after that is undefined behaviour.
+func (f *File) ResetString(s string) *File {
+	return f.ResetBytes(unsafe.Slice(unsafe.StringData(s), len(s))).SetReadOnly(true)
+}
+
+// Compact discards the bytes before the current position and moves the remaining bytes to the start of the internal buffer
+//
+// The capacity of the internal buffer is retained, so no reallocation occurs.
+// Slices previously returned by Bytes are invalidated.
+func (f *File) Compact() *File {
+	f.own("File.Compact")
+	f.updateChecksum()
+	n := copy(f.buf, f.buf[f.pos:])
+	f.buf = f.buf[:n]
+	f.shiftHoles(f.pos)
+	f.crcPos = max(f.crcPos-f.pos, 0)
+	f.pos = 0
+	return f
+}
+
+// Reader returns a read-only cursor over the internal buffer, starting at offset 0, with its own position
+//
+// Reading or seeking with it doesn't change f's position, so multiple Readers can be used concurrently,
+// as long as f isn't written to. It's a *bytes.Reader, so seeking past the end is allowed, and reads there return io.EOF.
+// It references the internal buffer at the time of the call, so it doesn't see bytes later added to the end.
+func (f *File) Reader() io.ReadSeeker {
+	return bytes.NewReader(f.buf)
+}
+
+// Fork returns a new File that shares the internal buffer, position and append mode with f, but not its counters
+//
+// Both Files are marked copy-on-write: reads never copy,
+// but the first write to either File copies the internal buffer before modifying it.
+// So unlike NewFile(f.Bytes()), writes to one File are never visible to the other.
+// Slices previously returned by Bytes, etc. continue to reference the original bytes.
+func (f *File) Fork() *File {
+	f.shared = true
+	return &File{
+		pos:        f.pos,
+		buf:        f.buf,
+		appendMode: f.appendMode,
+		holes:      slices.Clone(f.holes),
+		shared:     true,
+	}
+}
+
+// own copies the internal buffer if it may be shared by Fork, so it can be modified
+//
+// It panics with an error wrapping fs.ErrPermission or fs.ErrClosed if f is read-only or closed,
+// since it's only called by op before modifying the internal buffer.
+func (f *File) own(op string) {
+	if f.readOnly {
+		panic(&MemioError{Op: op, Err: fs.ErrPermission})
+	}
+	if f.closed {
+		panic(&MemioError{Op: op, Err: fs.ErrClosed})
+	}
+	if !f.shared {
+		return
+	}
+	s := make([]byte, len(f.buf), cap(f.buf))
+	copy(s, f.buf)
+	f.buf = s
+	f.shared = false
+	f.borrowed = false
+}
+
+// Section returns a new File sharing the internal buffer's bytes in range [off:off+n]
+//
+// Like io.SectionReader, reads from the section return io.EOF at its end even if the parent has more data.
+// The range is clamped to the bounds of the internal buffer.
+// Writes within the section are visible to the parent,
+// but writes that exceed its length reallocate and detach it from the parent.
+// If f shares its internal buffer with a Fork, the section is copy-on-write like the Fork,
+// so its first write detaches it instead.
+// The section is read-only if f is.
+func (f *File) Section(off, n int64) *File {
+	off = min(max(off, 0), int64(len(f.buf)))
+	end := off + min(max(n, 0), int64(len(f.buf))-off)
+	s := NewFile(f.buf[off:end:end]).SetReadOnly(f.readOnly)
+	s.shared = f.shared
+	s.borrowed = true
+	return s
+}
+
+// Tail returns the last n bytes of the internal buffer, or all of it if it's shorter than n
+//
+// It doesn't change the current position.
+// The slice is a reference to the internal buffer, so it's invalidated by subsequent writes.
+func (f *File) Tail(n int) []byte {
+	n = min(max(n, 0), len(f.buf))
+	return f.buf[len(f.buf)-n:]
+}
+
+// Slice returns the bytes in range [start:end] of the internal buffer, without changing the current position
+//
+// The slice is a reference to the internal buffer, so it's invalidated by subsequent writes.
+// An error wrapping fs.ErrInvalid is returned if the range is reversed or out of bounds.
+func (f *File) Slice(start, end int64) ([]byte, error) {
+	if start < 0 || start > end || end > int64(len(f.buf)) {
+		return nil, &MemioError{Op: "File.Slice", Err: fmt.Errorf("range [%d:%d] out of bounds: %w", start, end, fs.ErrInvalid)}
+	}
+	return f.buf[start:end:end], nil
+}
+
+// SliceCopy is like Slice, but returns a copy of the bytes
+func (f *File) SliceCopy(start, end int64) ([]byte, error) {
+	if start < 0 || start > end || end > int64(len(f.buf)) {
+		return nil, &MemioError{Op: "File.SliceCopy", Err: fmt.Errorf("range [%d:%d] out of bounds: %w", start, end, fs.ErrInvalid)}
+	}
+	return append([]byte(nil), f.buf[start:end]...), nil
+}
+
+// Truncate sets the buffer size to n
+//
+// If n is greater than Len(), the internal buffer is extended with zero bytes.
+// If the internal offset is greater than n, it's set to n.
 func (f *File) Truncate(n int) *File {
-	f.Seek(int64(n), io.SeekStart)
+	f.mustBeOpen("File.Truncate")
+	if n > len(f.buf) {
+		if n > f.sizeLimit() {
+			panic(&MemioError{Op: "File.Truncate", Err: ErrMaxSizeExceeded})
+		}
+		f.own("File.Truncate")
+		f.addHole(len(f.buf), n)
+		f.buf = append(f.buf, make([]byte, n-len(f.buf))...)
+	}
+	f.updateChecksum()
+	f.clipHoles(n)
 	f.buf = f.buf[:n]
+	f.crcPos = min(f.crcPos, n)
+	f.pos = min(f.pos, n)
+	return f
+}
+
+// EnsureLen extends the internal buffer with zero bytes until Len() is at least n, without changing the current position
+//
+// Unlike Truncate, it never shortens the internal buffer, so it's a no-op if Len() is already at least n.
+// The added bytes are recorded as a hole, like bytes skipped by Seek.
+func (f *File) EnsureLen(n int) *File {
+	if n > len(f.buf) {
+		f.Truncate(n)
+	}
 	return f
 }
 
 // Read implements io.Reader
+//
+// If p is empty, it returns (0, nil) even at the end of the internal buffer.
 func (f *File) Read(p []byte) (int, error) {
+	if f.closed {
+		return 0, &MemioError{Op: "File.Read", Err: fs.ErrClosed}
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if f.pos >= len(f.buf) {
+		return 0, io.EOF
+	}
+	n := copy(p, f.consume(min(len(p), len(f.buf)-f.pos)))
+	return n, nil
+}
+
+// ReadByte implements io.ByteReader
+func (f *File) ReadByte() (byte, error) {
+	if f.closed {
+		return 0, &MemioError{Op: "File.ReadByte", Err: fs.ErrClosed}
+	}
 	if f.pos >= len(f.buf) {
 		return 0, io.EOF
 	}
-	n := copy(p, f.buf[f.pos:])
-	f.pos += n
-	return n, nil
+	c := f.consume(1)[0]
+	f.unreadPos = f.pos
+	return c, nil
+}
+
+// UnreadByte implements io.ByteScanner
+//
+// It returns an error unless the most recent read was ReadByte, and the position hasn't changed since.
+func (f *File) UnreadByte() error {
+	if f.closed {
+		return &MemioError{Op: "File.UnreadByte", Err: fs.ErrClosed}
+	}
+	if f.unreadPos == 0 || f.unreadPos != f.pos {
+		return &MemioError{Op: "File.UnreadByte", Err: fmt.Errorf("previous operation was not ReadByte: %w", fs.ErrInvalid)}
+	}
+	f.unreadPos = 0
+	f.pos--
+	f.nread--
+	return nil
+}
+
+// UnreadN moves the current position back by n bytes, undoing the most recent reads
+//
+// It's the inverse of the read methods, e.g. to back up after reading past the end of a token.
+// Unlike UnreadByte, it works after any read, and doesn't check that the bytes were read rather than skipped by Seek.
+// An error wrapping fs.ErrInvalid is returned, and the position is unchanged, if n is negative or exceeds Offset().
+func (f *File) UnreadN(n int) error {
+	if f.closed {
+		return &MemioError{Op: "File.UnreadN", Err: fs.ErrClosed}
+	}
+	if n < 0 || n > f.pos {
+		return &MemioError{Op: "File.UnreadN", Err: fmt.Errorf("count(%d) out of range [0:%d]: %w", n, f.pos, fs.ErrInvalid)}
+	}
+	f.unreadPos = 0
+	f.pos -= n
+	f.nread = max(f.nread-int64(n), 0)
+	return nil
+}
+
+// mustBeOpen panics with an error wrapping fs.ErrClosed if f is closed, for methods like op that change it without returning an error
+func (f *File) mustBeOpen(op string) {
+	if f.closed {
+		panic(&MemioError{Op: op, Err: fs.ErrClosed})
+	}
+}
+
+// consume returns the next n bytes, and advances the current position past them
+//
+// n must not exceed the number of bytes remaining.
+// It panics if f is closed, so methods that return an error must check for that first.
+func (f *File) consume(n int) []byte {
+	if f.closed {
+		panic(&MemioError{Op: "File.Read", Err: fs.ErrClosed})
+	}
+	s := f.buf[f.pos : f.pos+n : f.pos+n]
+	f.pos += n
+	f.nread += int64(n)
+	f.unreadPos = 0
+	if f.readTee != nil && n > 0 {
+		if _, err := writeAll(f.readTee, s); err != nil {
+			f.readTee, f.readTeeErr = nil, err
+		}
+	}
+	return s
+}
+
+// readBytes implements ReadBytes and ReadString, returning a slice to the internal buffer
+func (f *File) readBytes(delim byte) ([]byte, error) {
+	if f.closed {
+		return nil, fs.ErrClosed
+	}
+	if f.pos >= len(f.buf) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if i := bytes.IndexByte(f.buf[f.pos:], delim); i >= 0 {
+		s := f.consume(i + 1) // skip over delim
+		return s[:i], nil
+	}
+	s := f.consume(len(f.buf) - f.pos)
+	return s, io.ErrUnexpectedEOF
+}
+
+// ReadBytes reads bytes up to and excluding delim, see ReadBytesInc to include it
+// An error (wrapping io.ErrUnexpectedEOF) is returned iff delim is not found
+func (f *File) ReadBytes(delim byte) ([]byte, error) {
+	p, err := f.readBytes(delim)
+	q := append([]byte(nil), p...)
+	if err != nil {
+		return q, &MemioError{Op: "File.ReadBytes", Err: err}
+	}
+	return q, nil
+}
+
+// ReadString reads bytes up to and excluding delim, see ReadStringInc to include it
+// An error (wrapping io.ErrUnexpectedEOF) is returned iff delim is not found
+func (f *File) ReadString(delim byte) (string, error) {
+	p, err := f.readBytes(delim)
+	q := string(p)
+	if err != nil {
+		return q, &MemioError{Op: "File.ReadString", Err: err}
+	}
+	return q, nil
+}
+
+// readBytesInc implements ReadBytesInc and ReadStringInc, returning a slice to the internal buffer
+func (f *File) readBytesInc(delim byte) ([]byte, error) {
+	if i := bytes.IndexByte(f.buf[f.pos:], delim); i >= 0 {
+		s := f.consume(i + 1)
+		return s, nil
+	}
+	s := f.consume(len(f.buf) - f.pos)
+	return s, io.EOF
+}
+
+// ReadBytesInc reads bytes up to and including delim, like bufio.Reader.ReadBytes
+//
+// Unlike ReadBytes, the delimiter is included in the result,
+// and io.EOF (unwrapped) is returned along with the remaining bytes iff delim is not found
+func (f *File) ReadBytesInc(delim byte) ([]byte, error) {
+	if f.closed {
+		return nil, &MemioError{Op: "File.ReadBytesInc", Err: fs.ErrClosed}
+	}
+	p, err := f.readBytesInc(delim)
+	return append([]byte(nil), p...), err
+}
+
+// ReadStringInc reads bytes up to and including delim, like bufio.Reader.ReadString
+//
+// Unlike ReadString, the delimiter is included in the result,
+// and io.EOF (unwrapped) is returned along with the remaining bytes iff delim is not found
+func (f *File) ReadStringInc(delim byte) (string, error) {
+	if f.closed {
+		return "", &MemioError{Op: "File.ReadStringInc", Err: fs.ErrClosed}
+	}
+	p, err := f.readBytesInc(delim)
+	return string(p), err
+}
+
+// ReadBytesLimit reads bytes up to and excluding delim, scanning at most max bytes
+//
+// If delim is not found within the first max bytes, the scanned bytes are returned with an error wrapping ErrLimitExceeded.
+// Otherwise, an error (wrapping io.ErrUnexpectedEOF) is returned iff delim is not found
+func (f *File) ReadBytesLimit(delim byte, max int) ([]byte, error) {
+	if f.closed {
+		return nil, &MemioError{Op: "File.ReadBytesLimit", Err: fs.ErrClosed}
+	}
+	if max < 0 {
+		return nil, &MemioError{Op: "File.ReadBytesLimit", Err: fmt.Errorf("negative max(%d): %w", max, fs.ErrInvalid)}
+	}
+	if f.pos >= len(f.buf) {
+		return nil, &MemioError{Op: "File.ReadBytesLimit", Err: io.ErrUnexpectedEOF}
+	}
+	s := f.buf[f.pos:]
+	limited := len(s) > max
+	if limited {
+		s = s[:max]
+	}
+	if i := bytes.IndexByte(s, delim); i >= 0 {
+		s = f.consume(i + 1) // skip over delim
+		return append([]byte(nil), s[:i]...), nil
+	}
+	q := append([]byte(nil), f.consume(len(s))...)
+	if limited {
+		return q, &MemioError{Op: "File.ReadBytesLimit", Err: fmt.Errorf("delim not found within %d bytes: %w", max, ErrLimitExceeded)}
+	}
+	return q, &MemioError{Op: "File.ReadBytesLimit", Err: io.ErrUnexpectedEOF}
+}
+
+// ReadCString reads a NUL-terminated string, scanning at most max bytes, including the NUL
+//
+// The NUL is consumed, but not included in the result.
+// If there's no NUL within the first max bytes, the scanned bytes are returned with an error wrapping ErrLimitExceeded.
+// Otherwise, an error (wrapping io.ErrUnexpectedEOF) is returned iff there's no NUL.
+func (f *File) ReadCString(max int) (string, error) {
+	if f.closed {
+		return "", &MemioError{Op: "File.ReadCString", Err: fs.ErrClosed}
+	}
+	if max < 0 {
+		return "", &MemioError{Op: "File.ReadCString", Err: fmt.Errorf("negative max(%d): %w", max, fs.ErrInvalid)}
+	}
+	s := f.buf[f.pos:]
+	limited := len(s) > max
+	if limited {
+		s = s[:max]
+	}
+	if i := bytes.IndexByte(s, 0); i >= 0 {
+		s = f.consume(i + 1) // skip over NUL
+		return string(s[:i]), nil
+	}
+	q := string(f.consume(len(s)))
+	if limited {
+		return q, &MemioError{Op: "File.ReadCString", Err: fmt.Errorf("NUL not found within %d bytes: %w", max, ErrLimitExceeded)}
+	}
+	return q, &MemioError{Op: "File.ReadCString", Err: io.ErrUnexpectedEOF}
+}
+
+// ReadUntilAny reads bytes up to and excluding the first byte that's in delims, returning the delimiter that was found
+//
+// The delimiter is consumed, but not included in the result.
+// If none of delims is found, the remaining bytes are returned with an error wrapping io.ErrUnexpectedEOF.
+func (f *File) ReadUntilAny(delims []byte) (data []byte, delim byte, err error) {
+	if f.closed {
+		return nil, 0, &MemioError{Op: "File.ReadUntilAny", Err: fs.ErrClosed}
+	}
+	var set [256]bool
+	for _, c := range delims {
+		set[c] = true
+	}
+	s := f.buf[f.pos:]
+	for i, c := range s {
+		if set[c] {
+			s = f.consume(i + 1) // skip over delim
+			return append([]byte(nil), s[:i]...), c, nil
+		}
+	}
+	q := append([]byte(nil), f.consume(len(s))...)
+	return q, 0, &MemioError{Op: "File.ReadUntilAny", Err: io.ErrUnexpectedEOF}
+}
+
+// ReadLine reads the next line, excluding the trailing "\n" or "\r\n"
+//
+// The final line is returned with a nil error even if it's not terminated by a newline.
+// io.EOF is returned iff there are no more lines to read.
+func (f *File) ReadLine() ([]byte, error) {
+	if f.closed {
+		return nil, &MemioError{Op: "File.ReadLine", Err: fs.ErrClosed}
+	}
+	if f.pos >= len(f.buf) {
+		return nil, io.EOF
+	}
+	p, _ := f.readBytes('\n')
+	p = bytes.TrimSuffix(p, []byte{'\r'})
+	return append([]byte(nil), p...), nil
+}
+
+// Lines reads the remaining lines, like ReadLine, and returns them as strings
+//
+// The position is advanced to the end of the internal buffer.
+// An empty slice is returned if there are no more lines to read.
+func (f *File) Lines() ([]string, error) {
+	if f.closed {
+		return nil, &MemioError{Op: "File.Lines", Err: fs.ErrClosed}
+	}
+	lines := []string{}
+	for f.pos < len(f.buf) {
+		p, _ := f.readBytes('\n')
+		lines = append(lines, string(bytes.TrimSuffix(p, []byte{'\r'})))
+	}
+	return lines, nil
+}
+
+// SplitN splits the remaining bytes into at most n pieces separated by delim, like bytes.SplitN, and advances the position to the end
+//
+// The last piece contains the unsplit remainder, including any further delimiters. If n <= 0, there's no limit.
+// The pieces are slices of the internal buffer, so they're invalidated by subsequent writes.
+// It returns nil if there are no bytes remaining.
+func (f *File) SplitN(delim byte, n int) [][]byte {
+	if f.pos >= len(f.buf) {
+		return nil
+	}
+	if n <= 0 {
+		n = -1
+	}
+	s := f.consume(len(f.buf) - f.pos)
+	return bytes.SplitN(s, []byte{delim}, n)
+}
+
+// Records returns an iterator over the records delimited by delim, starting at the current position
+//
+// Each record excludes delim and is a slice of the internal buffer, so it's invalidated by subsequent writes.
+// The final record is yielded even if it's not terminated by delim.
+func (f *File) Records(delim byte) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		if f.closed {
+			yield(nil, &MemioError{Op: "File.Records", Err: fs.ErrClosed})
+			return
+		}
+		for f.pos < len(f.buf) {
+			p, _ := f.readBytes(delim)
+			if !yield(p, nil) {
+				return
+			}
+		}
+	}
+}
+
+// readFull implements ReadFull, returning an unwrapped error
+func (f *File) readFull(p []byte) (int, error) {
+	if f.closed {
+		return 0, fs.ErrClosed
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if f.pos >= len(f.buf) {
+		return 0, ErrShortBuffer
+	}
+	n := copy(p, f.consume(min(len(p), len(f.buf)-f.pos)))
+	if n < len(p) {
+		return n, ErrShortBuffer
+	}
+	return n, nil
+}
+
+// ReadFull fills buffer p, or returns the number of bytes read and an error wrapping io.ErrUnexpectedEOF
+//
+// Like io.ReadFull, reading into an empty p always succeeds.
+func (f *File) ReadFull(p []byte) (int, error) {
+	n, err := f.readFull(p)
+	if err != nil {
+		return n, &MemioError{Op: "File.ReadFull", Err: err}
+	}
+	return n, nil
+}
+
+// ReadN reads the next n bytes, returning a slice of the internal buffer
+//
+// The slice is invalidated by subsequent writes.
+// If fewer than n bytes remain, they're returned along with an error wrapping io.ErrUnexpectedEOF.
+func (f *File) ReadN(n int) ([]byte, error) {
+	if f.closed {
+		return nil, &MemioError{Op: "File.ReadN", Err: fs.ErrClosed}
+	}
+	if n < 0 {
+		return nil, &MemioError{Op: "File.ReadN", Err: fmt.Errorf("negative count(%d): %w", n, fs.ErrInvalid)}
+	}
+	s := f.consume(min(n, len(f.buf)-f.pos))
+	if len(s) < n {
+		return s, &MemioError{Op: "File.ReadN", Err: ErrShortBuffer}
+	}
+	return s, nil
+}
+
+// ReadUint8 reads an 8-bit number
+func (f *File) ReadUint8() (uint8, error) {
+	p := [1]byte{}
+	if _, err := f.readFull(p[:]); err != nil {
+		return 0, &MemioError{Op: "File.ReadUint8", Err: err}
+	}
+	return p[0], nil
+}
+
+// ReadUint16 reads a 16-bit number in the byte order specified by o
+func (f *File) ReadUint16(o binary.ByteOrder) (uint16, error) {
+	p := [2]byte{}
+	if _, err := f.readFull(p[:]); err != nil {
+		return 0, &MemioError{Op: "File.ReadUint16", Err: err}
+	}
+	return o.Uint16(p[:]), nil
+}
+
+// isBigEndian reports whether o stores the most significant byte first
+func isBigEndian(o binary.ByteOrder) bool {
+	return o.Uint16([]byte{0, 1}) == 1
+}
+
+// nativeBigEndian is true if the host's byte order is big-endian
+var nativeBigEndian = isBigEndian(binary.NativeEndian)
+
+// isNativeOrder reports whether o is known to be the host's byte order, so numbers can be copied directly from memory
+func isNativeOrder(o binary.ByteOrder) bool {
+	switch o {
+	case binary.NativeEndian:
+		return true
+	case binary.LittleEndian:
+		return !nativeBigEndian
+	case binary.BigEndian:
+		return nativeBigEndian
+	default:
+		return false
+	}
+}
+
+// ReadUint24 reads a 24-bit number in the byte order specified by o, into the low 24 bits of the result
+func (f *File) ReadUint24(o binary.ByteOrder) (uint32, error) {
+	p := [4]byte{}
+	s := p[:3]
+	if isBigEndian(o) {
+		s = p[1:]
+	}
+	if _, err := f.readFull(s); err != nil {
+		return 0, &MemioError{Op: "File.ReadUint24", Err: err}
+	}
+	return o.Uint32(p[:]), nil
+}
+
+// ReadUint32 reads a 32-bit number in the byte order specified by o
+func (f *File) ReadUint32(o binary.ByteOrder) (uint32, error) {
+	p := [4]byte{}
+	if _, err := f.readFull(p[:]); err != nil {
+		return 0, &MemioError{Op: "File.ReadUint32", Err: err}
+	}
+	return o.Uint32(p[:]), nil
+}
+
+// ReadUint64 reads a 64-bit number in the byte order specified by o
+func (f *File) ReadUint64(o binary.ByteOrder) (uint64, error) {
+	p := [8]byte{}
+	if _, err := f.readFull(p[:]); err != nil {
+		return 0, &MemioError{Op: "File.ReadUint64", Err: err}
+	}
+	return o.Uint64(p[:]), nil
+}
+
+// ReadInt8 is a wrapper around int8(ReadUint8)
+func (f *File) ReadInt8() (int8, error) {
+	n, err := f.ReadUint8()
+	return int8(n), err
+}
+
+// ReadInt16 is a wrapper around int16(ReadUint16)
+func (f *File) ReadInt16(o binary.ByteOrder) (int16, error) {
+	n, err := f.ReadUint16(o)
+	return int16(n), err
+}
+
+// ReadInt32 is a wrapper around int32(ReadUint32)
+func (f *File) ReadInt32(o binary.ByteOrder) (int32, error) {
+	n, err := f.ReadUint32(o)
+	return int32(n), err
+}
+
+// ReadInt64 is a wrapper around int64(ReadUint64)
+func (f *File) ReadInt64(o binary.ByteOrder) (int64, error) {
+	n, err := f.ReadUint64(o)
+	return int64(n), err
+}
+
+// ReadFloat32 reads a 32-bit floating point number in the byte order specified by o
+func (f *File) ReadFloat32(o binary.ByteOrder) (float32, error) {
+	n, err := f.ReadUint32(o)
+	if err != nil {
+		return 0, &MemioError{Op: "File.ReadFloat32", Err: err}
+	}
+	return math.Float32frombits(n), nil
+}
+
+// ReadFloat64 reads a 64-bit floating point number in the byte order specified by o
+func (f *File) ReadFloat64(o binary.ByteOrder) (float64, error) {
+	n, err := f.ReadUint64(o)
+	if err != nil {
+		return 0, &MemioError{Op: "File.ReadFloat64", Err: err}
+	}
+	return math.Float64frombits(n), nil
+}
+
+// Expand grows the internal buffer to fill n bytes and sets pos to the end
+//
+// It returns a slice that should be filled with n bytes of content.
+// The slice may contain existing content or stale bytes from previously used capacity,
+// use ExpandZero if it should be zero-filled.
+// It panics if n is negative.
+// In append mode, the bytes are always added at the end of the internal buffer.
+func (f *File) Expand(n int) []byte {
+	if f.appendMode {
+		f.pos = len(f.buf)
+	}
+	checkCount("Expand", f.pos, n)
+	if n > f.sizeLimit()-f.pos {
+		panic(&MemioError{Op: "File.Expand", Err: ErrMaxSizeExceeded})
+	}
+	f.own("File.Expand")
+	f.updateChecksum()
+	n += f.pos
+	// pos never exceeds Len(), so any gap before it was already zero-filled by Seek, Truncate or expandAt,
+	// and the stale bytes exposed here are all returned to the caller to overwrite
+	if n > len(f.buf) {
+		f.buf = slices.Grow(f.buf, n-len(f.buf))[:n]
+	}
+	s := f.buf[f.pos:n]
+	f.fillHoles(f.pos, n)
+	f.nwritten += int64(n - f.pos)
+	f.pos = n
+	f.unreadPos = 0
+	return s
+}
+
+// checkCount panics if n is negative, or pos+n overflows
+func checkCount(op string, pos, n int) {
+	if n < 0 {
+		panic("memio: " + op + ": negative count")
+	}
+	if pos > math.MaxInt-n {
+		panic("memio: " + op + ": count too large")
+	}
+}
+
+// ExpandZero is like Expand, but the returned slice is zero-filled
+func (f *File) ExpandZero(n int) []byte {
+	s := f.Expand(n)
+	clear(s)
+	return s
+}
+
+// PatchFunc overwrites the bytes in range [off:off+size] with the result of fn, without changing the current position
+//
+// fn is passed a slice of the existing bytes and must return a replacement of the same length.
+// An error wrapping fs.ErrInvalid is returned if the range is out of bounds or the length differs.
+func (f *File) PatchFunc(off int64, size int, fn func(s []byte) []byte) error {
+	if f.closed {
+		return &MemioError{Op: "File.PatchFunc", Err: fs.ErrClosed}
+	}
+	if f.readOnly {
+		return &MemioError{Op: "File.PatchFunc", Err: fs.ErrPermission}
+	}
+	if off < 0 || size < 0 || off > int64(len(f.buf)-size) {
+		return &MemioError{Op: "File.PatchFunc", Err: fmt.Errorf("range [%d:%d+%d] out of bounds: %w", off, off, size, fs.ErrInvalid)}
+	}
+	f.own("File.PatchFunc")
+	f.updateChecksum()
+	s := f.buf[off : int(off)+size : int(off)+size]
+	p := fn(s)
+	if len(p) != size {
+		return &MemioError{Op: "File.PatchFunc", Err: fmt.Errorf("replacement length(%d) != size(%d): %w", len(p), size, fs.ErrInvalid)}
+	}
+	f.fillHoles(int(off), int(off)+size)
+	copy(s, p)
+	return nil
+}
+
+// Reserve writes n zero bytes as a placeholder, and returns the position they start at
+//
+// The mark can later be passed to PatchUint32, etc. to fill in the placeholder, e.g. with a length.
+// It panics if n is negative.
+func (f *File) Reserve(n int) (mark int64) {
+	s := f.ExpandZero(n)
+	return int64(f.pos - len(s))
+}
+
+// PatchUint32 overwrites the 4 bytes at mark with v in the byte order specified by o, without changing the current position
+//
+// An error wrapping fs.ErrInvalid is returned if mark+4 exceeds Len().
+func (f *File) PatchUint32(mark int64, o binary.ByteOrder, v uint32) error {
+	if f.closed {
+		return &MemioError{Op: "File.PatchUint32", Err: fs.ErrClosed}
+	}
+	if f.readOnly {
+		return &MemioError{Op: "File.PatchUint32", Err: fs.ErrPermission}
+	}
+	if mark < 0 || mark > int64(len(f.buf)-4) {
+		return &MemioError{Op: "File.PatchUint32", Err: fmt.Errorf("mark(%d)+4 out of bounds: %w", mark, fs.ErrInvalid)}
+	}
+	f.own("File.PatchUint32")
+	f.updateChecksum()
+	f.fillHoles(int(mark), int(mark)+4)
+	o.PutUint32(f.buf[mark:mark+4], v)
+	return nil
+}
+
+// Grow increases the capacity of the internal buffer to guarantee space for another n byte without reallocation
+//
+// The space is counted from the current position, or the end of the internal buffer in append mode,
+// and limited by the size set by SetMaxSize.
+// Calling it again with the same n is a no-op, since the space is already available.
+// It panics if n is negative.
+func (f *File) Grow(n int) *File {
+	pos := f.pos
+	if f.appendMode {
+		pos = len(f.buf)
+	}
+	checkCount("Grow", pos, n)
+	if n := min(pos+n, f.sizeLimit()) - len(f.buf); n > 0 {
+		f.buf = slices.Grow(f.buf, n)
+	}
+	return f
+}
+
+// Trim reallocates the internal buffer so its capacity equals its length, releasing any excess capacity
+//
+// It's equivalent to TrimTo(0)
+func (f *File) Trim() *File {
+	return f.TrimTo(0)
+}
+
+// TrimTo reallocates the internal buffer with capacity max(Len(), n) if its capacity exceeds it
+func (f *File) TrimTo(n int) *File {
+	n = max(len(f.buf), n)
+	if cap(f.buf) <= n {
+		return f
+	}
+	if n == 0 {
+		f.buf = nil
+		return f
+	}
+	s := make([]byte, len(f.buf), n)
+	copy(s, f.buf)
+	f.buf = s
+	return f
+}
+
+// ReadFrom implements io.ReaderFrom
+//
+// The data is written at the current position, like Write.
+// If r has a Len() int or Size() int64 method, like bytes.Reader, the internal buffer is grown once up front to fit it.
+// Otherwise, or if the size is exceeded, the internal buffer is grown in chunks that double in size, up to 1MiB, as more data arrives.
+func (f *File) ReadFrom(r io.Reader) (n int64, err error) {
+	if f.closed {
+		return 0, &MemioError{Op: "File.ReadFrom", Err: fs.ErrClosed}
+	}
+	if f.readOnly {
+		return 0, &MemioError{Op: "File.ReadFrom", Err: fs.ErrPermission}
+	}
+	if f.appendMode {
+		f.pos = len(f.buf)
+	}
+	f.own("File.ReadFrom")
+	f.updateChecksum()
+	lim := f.sizeLimit()
+	if hint := sizeHint(r); hint > 0 {
+		// leave room for the read that reports io.EOF, so it doesn't trigger another allocation
+		f.Grow(min(hint+minReadChunk, lim-f.pos))
+	}
+	chunk := minReadChunk
+	for {
+		if cap(f.buf)-f.pos < minReadChunk && cap(f.buf) < lim {
+			f.buf = slices.Grow(f.buf, min(f.pos+chunk, lim)-len(f.buf))
+			chunk = min(chunk*2, maxReadChunk)
+		}
+		dst := f.buf[f.pos:min(cap(f.buf), lim)]
+		if len(dst) == 0 {
+			// the internal buffer is at the max size, so any more data is an error
+			p := [1]byte{}
+			m, err := r.Read(p[:])
+			if m > 0 {
+				return n, &MemioError{Op: "File.ReadFrom", Err: ErrMaxSizeExceeded}
+			}
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return n, nil
+				}
+				return n, err
+			}
+			continue
+		}
+		m, err := r.Read(dst)
+		if m < 0 {
+			panic(fmt.Sprintf("%T.Read() returned negative count %d", r, m))
+		}
+		f.fillHoles(f.pos, f.pos+m)
+		f.pos += m
+		f.buf = f.buf[:max(len(f.buf), f.pos)]
+		f.nwritten += int64(m)
+		n += int64(m)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return n, nil
+			}
+			return n, err
+		}
+	}
+}
+
+// sizeHint returns the number of bytes r reports it has remaining, or 0 if it's unknown
+func sizeHint(r io.Reader) int {
+	var n int64
+	switch r := r.(type) {
+	case interface{ Len() int }:
+		n = int64(r.Len())
+	case interface{ Size() int64 }:
+		n = r.Size()
+	}
+	return int(min(max(n, 0), maxSeekLen))
+}
+
+// CopyFrom reads from r until io.EOF, writing the data at the current position, like io.CopyBuffer
+//
+// Unlike ReadFrom, data is read into buf before it's written with Write,
+// so the scratch buffer can be reused across calls and the internal buffer only grows as needed to fit the data.
+// If buf is nil, a 32KiB scratch buffer is allocated. An error wrapping fs.ErrInvalid is returned if buf is empty but not nil.
+func (f *File) CopyFrom(r io.Reader, buf []byte) (int64, error) {
+	if buf == nil {
+		buf = make([]byte, 32<<10)
+	} else if len(buf) == 0 {
+		return 0, &MemioError{Op: "File.CopyFrom", Err: fmt.Errorf("empty buffer: %w", fs.ErrInvalid)}
+	}
+	n := int64(0)
+	for {
+		m, err := r.Read(buf)
+		if m < 0 {
+			panic(fmt.Sprintf("%T.Read() returned negative count %d", r, m))
+		}
+		if m > 0 {
+			if _, err := f.Write(buf[:m]); err != nil {
+				return n, err
+			}
+			n += int64(m)
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return n, nil
+			}
+			return n, &MemioError{Op: "File.CopyFrom", Err: err}
+		}
+	}
+}
+
+// ReadFromN reads exactly n bytes from r into the internal buffer at the current position
+//
+// The internal buffer is grown at most once, and no more than n bytes are read from r.
+// If r ends before n bytes are read, the number of bytes read and an error wrapping io.ErrUnexpectedEOF are returned.
+func (f *File) ReadFromN(r io.Reader, n int64) (int64, error) {
+	if f.closed {
+		return 0, &MemioError{Op: "File.ReadFromN", Err: fs.ErrClosed}
+	}
+	if f.readOnly {
+		return 0, &MemioError{Op: "File.ReadFromN", Err: fs.ErrPermission}
+	}
+	if n < 0 || n > math.MaxInt {
+		return 0, &MemioError{Op: "File.ReadFromN", Err: fmt.Errorf("invalid count(%d): %w", n, fs.ErrInvalid)}
+	}
+	var limitErr error
+	if room := int64(f.sizeRoom()); n > room {
+		n = room
+		limitErr = &MemioError{Op: "File.ReadFromN", Err: ErrMaxSizeExceeded}
+	}
+	size, holes := len(f.buf), f.holes
+	s := f.Expand(int(n))
+	m, err := io.ReadFull(r, s)
+	if m < len(s) {
+		f.shrinkExpand(f.pos-len(s), m, len(s), size, holes)
+	}
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return int64(m), &MemioError{Op: "File.ReadFromN", Err: err}
+	}
+	return int64(m), limitErr
+}
+
+// StreamFrom reads from r in chunks of up to chunk bytes, passing each chunk to fn until r returns io.EOF
+//
+// The internal buffer is reset before each chunk is read, so the data is not retained between calls to fn.
+// If fn returns an error, StreamFrom stops and returns it unwrapped, along with the number of bytes read so far.
+func (f *File) StreamFrom(r io.Reader, chunk int, fn func(p []byte) error) (int64, error) {
+	if f.closed {
+		return 0, &MemioError{Op: "File.StreamFrom", Err: fs.ErrClosed}
+	}
+	if f.readOnly {
+		return 0, &MemioError{Op: "File.StreamFrom", Err: fs.ErrPermission}
+	}
+	if chunk <= 0 {
+		return 0, &MemioError{Op: "File.StreamFrom", Err: fmt.Errorf("invalid chunk size(%d): %w", chunk, fs.ErrInvalid)}
+	}
+	n := int64(0)
+	for {
+		c := min(chunk, f.Reset().sizeLimit())
+		f.Grow(c).own("File.StreamFrom")
+		m, err := r.Read(f.buf[:c])
+		if m < 0 {
+			panic(fmt.Sprintf("%T.Read() returned negative count %d", r, m))
+		}
+		f.buf = f.buf[:m]
+		f.pos = m
+		f.nwritten += int64(m)
+		n += int64(m)
+		if m > 0 {
+			if err := fn(f.buf); err != nil {
+				return n, err
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return n, nil
+			}
+			return n, &MemioError{Op: "File.StreamFrom", Err: err}
+		}
+	}
+}
+
+// writeAll writes s to w, retrying partial writes until all of s is written or w returns an error
+//
+// If w makes no progress without returning an error, io.ErrShortWrite is returned.
+func writeAll(w io.Writer, s []byte) (int, error) {
+	n := 0
+	for n < len(s) {
+		m, err := w.Write(s[n:])
+		if m < 0 || m > len(s)-n {
+			panic(fmt.Sprintf("%T.Write() returned invalid count %d", w, m))
+		}
+		n += m
+		if err != nil {
+			return n, err
+		}
+		if m == 0 {
+			return n, io.ErrShortWrite
+		}
+	}
+	return n, nil
+}
+
+// WriteTo implements io.WriterTo
+//
+// Partial writes are retried until all bytes from the current position are written, or w returns an error.
+func (f *File) WriteTo(w io.Writer) (int64, error) {
+	if f.closed {
+		return 0, &MemioError{Op: "File.WriteTo", Err: fs.ErrClosed}
+	}
+	n, err := writeAll(w, f.buf[f.pos:])
+	f.consume(n)
+	if err != nil {
+		return int64(n), &MemioError{Op: "File.WriteTo", Err: err}
+	}
+	return int64(n), nil
+}
+
+// DrainTo writes the bytes from the current position to w, like WriteTo, then resets the File
+//
+// The File is only reset if all bytes were written successfully,
+// otherwise the current position is advanced by the number of bytes written.
+func (f *File) DrainTo(w io.Writer) (int64, error) {
+	n, err := f.WriteTo(w)
+	if err != nil {
+		return n, wrapErr("File.DrainTo", err)
+	}
+	f.Reset()
+	return n, nil
+}
+
+// CopyAllTo writes the entire internal buffer to w, without changing the current position
+//
+// Unlike WriteTo, it ignores the current position,
+// so it can be used to e.g. compute a checksum of the whole content mid-parse.
+func (f *File) CopyAllTo(w io.Writer) (int64, error) {
+	if f.closed {
+		return 0, &MemioError{Op: "File.CopyAllTo", Err: fs.ErrClosed}
+	}
+	n, err := writeAll(w, f.buf)
+	if err != nil {
+		return int64(n), &MemioError{Op: "File.CopyAllTo", Err: err}
+	}
+	return int64(n), nil
+}
+
+// WriteRangeTo writes the bytes in range [start:end] of the internal buffer to w, without changing the current position
+//
+// Partial writes are retried, like WriteTo.
+// An error wrapping fs.ErrInvalid is returned if the range is reversed or out of bounds.
+func (f *File) WriteRangeTo(w io.Writer, start, end int64) (int64, error) {
+	if f.closed {
+		return 0, &MemioError{Op: "File.WriteRangeTo", Err: fs.ErrClosed}
+	}
+	if start < 0 || start > end || end > int64(len(f.buf)) {
+		return 0, &MemioError{Op: "File.WriteRangeTo", Err: fmt.Errorf("range [%d:%d] out of bounds: %w", start, end, fs.ErrInvalid)}
+	}
+	n, err := writeAll(w, f.buf[start:end])
+	if err != nil {
+		return int64(n), &MemioError{Op: "File.WriteRangeTo", Err: err}
+	}
+	return int64(n), nil
+}
+
+// Write implements io.Writer
+func (f *File) Write(p []byte) (int, error) {
+	if f.closed {
+		return 0, &MemioError{Op: "File.Write", Err: fs.ErrClosed}
+	}
+	if f.readOnly {
+		return 0, &MemioError{Op: "File.Write", Err: fs.ErrPermission}
+	}
+	if n := f.sizeRoom(); len(p) > n {
+		return copy(f.Expand(n), p), &MemioError{Op: "File.Write", Err: ErrMaxSizeExceeded}
+	}
+	return copy(f.Expand(len(p)), p), nil
+}
+
+// WriteOffset is like Write, but also returns the offset at which p was written
+//
+// In append mode, the offset is the end of the internal buffer rather than the current position.
+// It's useful for building a table of offsets while writing the records they point to.
+func (f *File) WriteOffset(p []byte) (off int64, err error) {
+	off = f.At()
+	_, err = f.Write(p)
+	return off, err
+}
+
+// At returns the offset at which the next write begins
+//
+// It's the same as Offset(), except in append mode, where it's Len().
+func (f *File) At() int64 {
+	if f.appendMode {
+		return int64(len(f.buf))
+	}
+	return int64(f.pos)
+}
+
+// WriteTracked is like Write, but also reports whether the internal buffer was reallocated
+//
+// It's intended as a diagnostic aid, e.g. for tuning calls to Grow.
+func (f *File) WriteTracked(p []byte) (n int, grew bool, err error) {
+	data, size := unsafe.SliceData(f.buf), cap(f.buf)
+	n, err = f.Write(p)
+	return n, unsafe.SliceData(f.buf) != data || cap(f.buf) != size, err
+}
+
+// WriteString implements io.StringWriter
+func (f *File) WriteString(p string) (int, error) {
+	if f.closed {
+		return 0, &MemioError{Op: "File.WriteString", Err: fs.ErrClosed}
+	}
+	if f.readOnly {
+		return 0, &MemioError{Op: "File.WriteString", Err: fs.ErrPermission}
+	}
+	if n := f.sizeRoom(); len(p) > n {
+		return copy(f.Expand(n), p), &MemioError{Op: "File.WriteString", Err: ErrMaxSizeExceeded}
+	}
+	s := f.Expand(len(p))
+	n := copy(s, p)
+	return n, nil
+}
+
+// WriteCString writes s followed by a NUL byte
+//
+// An error wrapping fs.ErrInvalid is returned, and nothing is written, if s contains a NUL.
+func (f *File) WriteCString(s string) error {
+	if f.closed {
+		return &MemioError{Op: "File.WriteCString", Err: fs.ErrClosed}
+	}
+	if f.readOnly {
+		return &MemioError{Op: "File.WriteCString", Err: fs.ErrPermission}
+	}
+	if i := strings.IndexByte(s, 0); i >= 0 {
+		return &MemioError{Op: "File.WriteCString", Err: fmt.Errorf("NUL at index(%d): %w", i, fs.ErrInvalid)}
+	}
+	if f.sizeRoom() <= len(s) {
+		return &MemioError{Op: "File.WriteCString", Err: ErrMaxSizeExceeded}
+	}
+	p := f.Expand(len(s) + 1)
+	p[copy(p, s)] = 0
+	return nil
+}
+
+// expandAt returns a slice of the n bytes at offset off, without changing the current position
+//
+// If off+n is greater than Len(), the internal buffer is extended with zero bytes.
+// If it would exceed the size set by SetMaxSize, the slice is shortened to fit and returned with an error.
+func (f *File) expandAt(op string, off int64, n int) ([]byte, error) {
+	if f.closed {
+		return nil, &MemioError{Op: op, Err: fs.ErrClosed}
+	}
+	if f.readOnly {
+		return nil, &MemioError{Op: op, Err: fs.ErrPermission}
+	}
+	if off < 0 {
+		return nil, &MemioError{Op: op, Err: fmt.Errorf("negative offset(%d): %w", off, fs.ErrInvalid)}
+	}
+	if off > int64(max(len(f.buf), maxSeekLen)-n) {
+		return nil, &MemioError{Op: op, Err: fmt.Errorf("offset(%d) exceeds maximum(%d): %w", off, maxSeekLen, fs.ErrInvalid)}
+	}
+	var err error
+	if lim := f.sizeLimit(); off+int64(n) > int64(lim) {
+		if off > int64(lim) {
+			return nil, &MemioError{Op: op, Err: ErrMaxSizeExceeded}
+		}
+		n = lim - int(off)
+		err = &MemioError{Op: op, Err: ErrMaxSizeExceeded}
+	}
+	f.own(op)
+	f.updateChecksum()
+	end := int(off) + n
+	if end > len(f.buf) {
+		f.addHole(len(f.buf), int(off))
+		f.buf = append(f.buf, make([]byte, end-len(f.buf))...)
+	}
+	f.fillHoles(int(off), end)
+	f.nwritten += int64(n)
+	return f.buf[off:end], err
+}
+
+// WriteAt implements io.WriterAt
+//
+// It doesn't change the current position.
+// If off is greater than Len(), the gap is filled with zero bytes.
+func (f *File) WriteAt(p []byte, off int64) (int, error) {
+	s, err := f.expandAt("File.WriteAt", off, len(p))
+	return copy(s, p), err
+}
+
+// WriteStringAt is like WriteAt, but writes a string
+func (f *File) WriteStringAt(p string, off int64) (int, error) {
+	s, err := f.expandAt("File.WriteStringAt", off, len(p))
+	return copy(s, p), err
+}
+
+// WriteFuncN calls fn with an empty slice of capacity max at the current position, and writes the bytes fn appends to it
+//
+// fn must append at most max bytes to dst, without reallocating it, and return the number of bytes appended.
+// It's intended for zero-copy appends, like dst = t.AppendFormat(dst, layout); return len(dst).
+// It panics if max is negative, or fn returns a count outside the range [0, max].
+func (f *File) WriteFuncN(max int, fn func(dst []byte) int) *File {
+	size, holes := len(f.buf), f.holes
+	s := f.Expand(max)
+	n := fn(s[:0:max])
+	if n < 0 || n > max {
+		panic("memio: WriteFuncN: invalid count")
+	}
+	f.shrinkExpand(f.pos-len(s), n, max, size, holes)
+	return f
+}
+
+// shrinkExpand undoes the part of an Expand of n bytes at start that wasn't written, keeping only the first used bytes
+//
+// size and holes are the length and holes of the internal buffer before the Expand,
+// so bytes that weren't written are restored to their previous length and holes.
+func (f *File) shrinkExpand(start, used, n, size int, holes []span) {
+	f.pos = start + used
+	f.buf = f.buf[:max(size, f.pos)]
+	f.nwritten -= int64(n - used)
+	// fillHoles replaces f.holes instead of modifying it, so holes is unchanged by the Expand
+	f.holes = holes
+	f.fillHoles(start, f.pos)
+	f.clipHoles(len(f.buf))
+}
+
+// AppendFile writes the whole internal buffer of other, like Write(other.Bytes())
+//
+// The position of other is ignored and not changed. other may be f.
+func (f *File) AppendFile(other *File) *File {
+	f.Write(other.buf)
+	return f
+}
+
+// WriteFileAt writes the whole internal buffer of other at offset off, like WriteAt(other.Bytes(), off)
+//
+// The position of other is ignored and not changed.
+func (f *File) WriteFileAt(other *File, off int64) (int, error) {
+	s, err := f.expandAt("File.WriteFileAt", off, len(other.buf))
+	return copy(s, other.buf), err
+}
+
+// WriteByte implements io.ByteWriter
+func (f *File) WriteByte(p byte) error {
+	if f.closed {
+		return &MemioError{Op: "File.WriteByte", Err: fs.ErrClosed}
+	}
+	if f.readOnly {
+		return &MemioError{Op: "File.WriteByte", Err: fs.ErrPermission}
+	}
+	if f.sizeRoom() < 1 {
+		return &MemioError{Op: "File.WriteByte", Err: ErrMaxSizeExceeded}
+	}
+	s := f.Expand(1)
+	s[0] = p
+	return nil
 }
 
-// ReadByte implements io.ByteReader
-func (f *File) ReadByte() (byte, error) {
-	if f.pos >= len(f.buf) {
-		return 0, io.EOF
+// WriteRune writes the UTF-8 encoding of r, like bufio.Writer.WriteRune
+//
+// Invalid runes are written as utf8.RuneError.
+// It returns the number of bytes written.
+func (f *File) WriteRune(r rune) (int, error) {
+	if f.closed {
+		return 0, &MemioError{Op: "File.WriteRune", Err: fs.ErrClosed}
 	}
-	c := f.buf[f.pos]
-	f.pos += 1
-	return c, nil
-}
-
-// readBytes implements ReadBytes and ReadString, returning a slice to the internal buffer
-func (f *File) readBytes(delim byte) ([]byte, error) {
-	if f.pos >= len(f.buf) {
-		return nil, io.ErrUnexpectedEOF
+	if f.readOnly {
+		return 0, &MemioError{Op: "File.WriteRune", Err: fs.ErrPermission}
 	}
-	if i := bytes.IndexByte(f.buf[f.pos:], delim); i >= 0 {
-		s := f.buf[f.pos : f.pos+i]
-		f.pos += i + 1 // skip over delim
-		return s, nil
+	p := [utf8.UTFMax]byte{}
+	n := utf8.EncodeRune(p[:], r)
+	if f.sizeRoom() < n {
+		return 0, &MemioError{Op: "File.WriteRune", Err: ErrMaxSizeExceeded}
 	}
-	s := f.buf[f.pos:]
-	f.pos = len(f.buf)
-	return s, io.ErrUnexpectedEOF
+	return copy(f.Expand(n), p[:n]), nil
 }
 
-// ReadBytes reads bytes up to and excluding delim
-// An error (wrapping io.ErrUnexpectedEOF) is returned iff delim is not found
-func (f *File) ReadBytes(delim byte) ([]byte, error) {
-	p, err := f.readBytes(delim)
-	q := append([]byte(nil), p...)
-	if err != nil {
-		return q, fmt.Errorf("File.ReadBytes: %w", err)
-	}
-	return q, nil
+// PrintRune is like WriteRune, but returns f for chaining
+//
+// Like the other writes, it overwrites the bytes at the current position,
+// extending the internal buffer only if it writes past the end.
+func (f *File) PrintRune(r rune) *File {
+	f.WriteRune(r)
+	return f
 }
 
-// ReadString reads bytes up to and excluding delim
-// An error (wrapping io.ErrUnexpectedEOF) is returned iff delim is not found
-func (f *File) ReadString(delim byte) (string, error) {
-	p, err := f.readBytes(delim)
-	q := string(p)
-	if err != nil {
-		return q, fmt.Errorf("File.ReadString: %w", err)
-	}
-	return q, nil
+// PrintString is like WriteString, but returns f for chaining
+//
+// Like WriteString, it overwrites the bytes at the current position,
+// extending the internal buffer only if it writes past the end.
+func (f *File) PrintString(s string) *File {
+	copy(f.printExpand(len(s)), s)
+	return f
 }
 
-// ReadFull fills buffer p, or returns the number of bytes read and error io.ErrUnexpectedEOF
-func (f *File) ReadFull(p []byte) (int, error) {
-	if f.pos >= len(f.buf) {
-		return 0, io.ErrUnexpectedEOF
-	}
-	n := copy(p, f.buf[f.pos:])
-	f.pos += n
-	if n < len(p) {
-		return n, io.ErrUnexpectedEOF
+// printExpand is like Expand, but returns nil instead of panicking if f is read-only or closed,
+// or the n bytes don't fit within the size set by SetMaxSize
+//
+// The Print methods use it so that, like PrintRune, they write nothing if the write would fail.
+func (f *File) printExpand(n int) []byte {
+	if f.readOnly || f.closed || n > f.sizeRoom() {
+		return nil
 	}
-	return n, nil
+	return f.Expand(n)
 }
 
-// ReadUint16 reads a 16-bit number in the byte order specified by o
-func (f *File) ReadUint16(o binary.ByteOrder) (uint16, error) {
-	p := [2]byte{}
-	if _, err := f.ReadFull(p[:]); err != nil {
-		return 0, fmt.Errorf("File.ReadUint16: %w", err)
+// PrintLine writes the concatenation of a, followed by a newline
+func (f *File) PrintLine(a ...string) *File {
+	n := 1
+	for _, s := range a {
+		n += len(s)
 	}
-	return o.Uint16(p[:]), nil
+	p := f.printExpand(n)
+	if p == nil {
+		return f
+	}
+	i := 0
+	for _, s := range a {
+		i += copy(p[i:], s)
+	}
+	p[i] = '\n'
+	return f
 }
 
-// ReadUint32 reads a 32-bit number in the byte order specified by o
-func (f *File) ReadUint32(o binary.ByteOrder) (uint32, error) {
-	p := [4]byte{}
-	if _, err := f.ReadFull(p[:]); err != nil {
-		return 0, fmt.Errorf("File.ReadUint32: %w", err)
-	}
-	return o.Uint32(p[:]), nil
+// PrintLinef writes a line formatted by fmt.Fprintf, followed by a newline
+func (f *File) PrintLinef(format string, args ...any) *File {
+	p := fmt.Appendf(nil, format, args...)
+	p = append(p, '\n')
+	copy(f.printExpand(len(p)), p)
+	return f
 }
 
-// ReadUint64 reads a 64-bit number in the byte order specified by o
-func (f *File) ReadUint64(o binary.ByteOrder) (uint64, error) {
-	p := [8]byte{}
-	if _, err := f.ReadFull(p[:]); err != nil {
-		return 0, fmt.Errorf("File.ReadUint64: %w", err)
+// PrintRepeat writes count copies of the UTF-8 encoding of r
+//
+// Invalid runes are written as utf8.RuneError.
+// It's a no-op if count is 0, and panics if count is negative.
+func (f *File) PrintRepeat(r rune, count int) *File {
+	p := [utf8.UTFMax]byte{}
+	n := utf8.EncodeRune(p[:], r)
+	if count < 0 {
+		panic("memio: PrintRepeat: negative count")
 	}
-	return o.Uint64(p[:]), nil
+	if count > math.MaxInt/n {
+		panic("memio: PrintRepeat: count too large")
+	}
+	s := f.printExpand(n * count)
+	if len(s) == 0 {
+		return f
+	}
+	// copy the encoding once, then double the filled prefix
+	for i := copy(s, p[:n]); i < len(s); i *= 2 {
+		copy(s[i:], s[:i])
+	}
+	return f
 }
 
-// ReadInt16 is a wrapper around int16(ReadUint16)
-func (f *File) ReadInt16(o binary.ByteOrder) (int16, error) {
-	n, err := f.ReadUint16(o)
-	return int16(n), err
+// PrintInt writes the base 10 representation of n, like fmt.Fprintf(f, "%d", n) but without the overhead of fmt
+func (f *File) PrintInt(n int64) *File {
+	p := [24]byte{}
+	s := strconv.AppendInt(p[:0], n, 10)
+	copy(f.printExpand(len(s)), s)
+	return f
 }
 
-// ReadInt32 is a wrapper around int32(ReadUint32)
-func (f *File) ReadInt32(o binary.ByteOrder) (int32, error) {
-	n, err := f.ReadUint32(o)
-	return int32(n), err
+// PrintUint writes the base 10 representation of n, like fmt.Fprintf(f, "%d", n) but without the overhead of fmt
+func (f *File) PrintUint(n uint64) *File {
+	p := [24]byte{}
+	s := strconv.AppendUint(p[:0], n, 10)
+	copy(f.printExpand(len(s)), s)
+	return f
 }
 
-// ReadInt64 is a wrapper around int64(ReadUint64)
-func (f *File) ReadInt64(o binary.ByteOrder) (int64, error) {
-	n, err := f.ReadUint64(o)
-	return int64(n), err
+// PrintFloat writes the representation of v as formatted by strconv.FormatFloat(v, fmt, prec, 64)
+//
+// PrintFloat(v, 'g', -1) produces the same output as fmt.Fprintf(f, "%g", v)
+func (f *File) PrintFloat(v float64, fmt byte, prec int) *File {
+	p := [32]byte{}
+	s := strconv.AppendFloat(p[:0], v, fmt, prec, 64)
+	copy(f.printExpand(len(s)), s)
+	return f
 }
 
-// ReadFloat32 reads a 32-bit floating point number in the byte order specified by o
-func (f *File) ReadFloat32(o binary.ByteOrder) (float32, error) {
-	n, err := f.ReadUint32(o)
-	if err != nil {
-		return 0, fmt.Errorf("File.ReadFloat32: %w", err)
+// WriteFill writes n copies of b
+func (f *File) WriteFill(b byte, n int) *File {
+	s := f.Expand(n)
+	for i := range s {
+		s[i] = b
 	}
-	return math.Float32frombits(n), nil
+	return f
 }
 
-// ReadFloat64 reads a 64-bit floating point number in the byte order specified by o
-func (f *File) ReadFloat64(o binary.ByteOrder) (float64, error) {
-	n, err := f.ReadUint64(o)
-	if err != nil {
-		return 0, fmt.Errorf("File.ReadFloat64: %w", err)
+// WritePad writes copies of b at the end of the internal buffer until Len() reaches to
+//
+// If Len() is already at least to, it's a no-op. Otherwise, the position is set to the new end.
+func (f *File) WritePad(b byte, to int) *File {
+	if n := to - len(f.buf); n > 0 {
+		f.pos = len(f.buf)
+		f.WriteFill(b, n)
 	}
-	return math.Float64frombits(n), nil
+	return f
 }
 
-// Expand grows the internal buffer to fill n bytes and sets pos to the end
+// Align writes copies of pad until the current position is a multiple of n
 //
-// It returns a slice that should be filled with n bytes of content
-func (f *File) Expand(n int) []byte {
-	n += f.pos
-	f.buf = slices.Grow(f.buf, n)
-	if n > len(f.buf) {
-		f.buf = f.buf[:n]
+// In append mode, the end of the internal buffer is aligned instead.
+// It panics if n is not positive.
+func (f *File) Align(n int, pad byte) *File {
+	if n <= 0 {
+		panic("memio: Align: non-positive alignment")
+	}
+	pos := f.pos
+	if f.appendMode {
+		pos = len(f.buf)
+	}
+	if r := pos % n; r != 0 {
+		f.WriteFill(pad, n-r)
 	}
-	s := f.buf[f.pos:n]
-	f.pos = n
-	return s
-}
-
-// Grow increases the capacity of the internal buffer to guarantee space for another n byte without reallocation
-func (f *File) Grow(n int) *File {
-	f.buf = slices.Grow(f.buf, f.pos+n)
 	return f
 }
 
-// ReadFrom implements io.ReaderFrom
-func (f *File) ReadFrom(r io.Reader) (n int64, err error) {
-	for {
-		f.Grow(1 << 10)
-		m, err := r.Read(f.buf[f.pos:cap(f.buf)])
-		if m < 0 {
-			panic(fmt.Sprintf("%T.Read() returned negative count %d", r, m))
-		}
-		f.buf = f.buf[:f.pos+m]
-		n += int64(m)
-		if err != nil {
-			if errors.Is(err, io.EOF) {
-				return n, nil
-			}
-			return n, err
-		}
+// AlignRead advances the current position to the next multiple of n, skipping over the bytes in between
+//
+// An error wrapping fs.ErrInvalid is returned if n is not positive.
+// If the internal buffer ends before the aligned position, the position is set to the end
+// and an error wrapping io.ErrUnexpectedEOF is returned.
+func (f *File) AlignRead(n int) error {
+	if f.closed {
+		return &MemioError{Op: "File.AlignRead", Err: fs.ErrClosed}
 	}
-}
-
-// WriteTo implements io.WriterTo
-func (f *File) WriteTo(w io.Writer) (int64, error) {
-	if f.pos >= len(f.buf) {
-		return 0, fmt.Errorf("File.WriteTo: %w", io.ErrUnexpectedEOF)
+	if n <= 0 {
+		return &MemioError{Op: "File.AlignRead", Err: fmt.Errorf("non-positive alignment(%d): %w", n, fs.ErrInvalid)}
 	}
-	s := f.buf[f.pos:]
-	n, err := w.Write(s)
-	f.pos += n
-	if err != nil {
-		return int64(n), fmt.Errorf("File.WriteTo: %w", err)
+	skip := 0
+	if r := f.pos % n; r != 0 {
+		skip = n - r
 	}
-	if n < len(s) {
-		return int64(n), fmt.Errorf("File.WriteTo: %w", io.ErrShortWrite)
+	if rem := len(f.buf) - f.pos; skip > rem {
+		f.consume(rem)
+		return &MemioError{Op: "File.AlignRead", Err: ErrShortBuffer}
 	}
-	return int64(n), nil
-}
-
-// Seek implements io.Writer
-func (f *File) Write(p []byte) (int, error) {
-	return copy(f.Expand(len(p)), p), nil
-}
-
-// WriteString implements io.StringWriter
-func (f *File) WriteString(p string) (int, error) {
-	s := f.Expand(len(p))
-	n := copy(s, p)
-	return n, nil
+	f.consume(skip)
+	return nil
 }
 
-// WriteByte implements io.ByteWriter
-func (f *File) WriteByte(p byte) error {
+// WriteUint8 writes n
+func (f *File) WriteUint8(n uint8) {
 	s := f.Expand(1)
-	s[0] = p
-	return nil
+	s[0] = n
 }
 
 // WriteUint16 writes n in the byte order specified by o
@@ -270,6 +1695,19 @@ func (f *File) WriteUint16(o binary.ByteOrder, n uint16) {
 	o.PutUint16(s, n)
 }
 
+// WriteUint24 writes the low 24 bits of n in the byte order specified by o
+//
+// The high 8 bits of n are ignored.
+func (f *File) WriteUint24(o binary.ByteOrder, n uint32) {
+	p := [4]byte{}
+	o.PutUint32(p[:], n&0xffffff)
+	if isBigEndian(o) {
+		copy(f.Expand(3), p[1:])
+	} else {
+		copy(f.Expand(3), p[:3])
+	}
+}
+
 // WriteUint32 writes n in the byte order specified by o
 func (f *File) WriteUint32(o binary.ByteOrder, n uint32) {
 	s := f.Expand(4)
@@ -282,6 +1720,11 @@ func (f *File) WriteUint64(o binary.ByteOrder, n uint64) {
 	o.PutUint64(s, n)
 }
 
+// WriteInt8 is a wrapper around f.WriteUint8(uint8(n))
+func (f *File) WriteInt8(n int8) {
+	f.WriteUint8(uint8(n))
+}
+
 // WriteInt16 is a wrapper around f.WriteUint16(o, uint16(n))
 func (f *File) WriteInt16(o binary.ByteOrder, n int16) {
 	f.WriteUint16(o, uint16(n))
@@ -309,26 +1752,46 @@ func (f *File) WriteFloat32(o binary.ByteOrder, n float32) {
 
 // Seek implements io.Seeker
 //
-// If the final offset is greater than Len(), the internal buffer is expanded accordingly
+// If the final offset is greater than Len(), the internal buffer is expanded accordingly,
+// up to a maximum of math.MaxInt32 bytes
 func (f *File) Seek(offset int64, whence int) (int64, error) {
-	var sp int64
+	if f.closed {
+		return 0, &MemioError{Op: "File.Seek", Err: fs.ErrClosed}
+	}
+	var base int64
 	switch whence {
 	case io.SeekStart:
-		sp = offset
+		base = 0
 	case io.SeekCurrent:
-		sp = int64(f.pos) + offset
+		base = int64(f.pos)
 	case io.SeekEnd:
-		sp = int64(len(f.buf)) + offset
+		base = int64(len(f.buf))
 	default:
-		return 0, fmt.Errorf("File.Seek: invalid whence(%d): %w", whence, fs.ErrInvalid)
+		return 0, &MemioError{Op: "File.Seek", Err: fmt.Errorf("invalid whence(%d): %w", whence, fs.ErrInvalid)}
+	}
+	if offset > math.MaxInt64-base {
+		return 0, &MemioError{Op: "File.Seek", Err: fmt.Errorf("offset(%d) overflows: %w", offset, fs.ErrInvalid)}
 	}
+	sp := base + offset
 	if sp < 0 {
-		return 0, fmt.Errorf("File.Seek: negative offset(%d): %w", sp, fs.ErrInvalid)
+		return 0, &MemioError{Op: "File.Seek", Err: fmt.Errorf("negative offset(%d): %w", sp, fs.ErrInvalid)}
+	}
+	if sp > int64(len(f.buf)) && f.readOnly {
+		return 0, &MemioError{Op: "File.Seek", Err: fmt.Errorf("offset(%d) past the end: %w", sp, fs.ErrPermission)}
+	}
+	if sp > int64(len(f.buf)) && sp > int64(f.sizeLimit()) {
+		return 0, &MemioError{Op: "File.Seek", Err: fmt.Errorf("offset(%d) past the max size: %w", sp, ErrMaxSizeExceeded)}
+	}
+	if sp > int64(len(f.buf)) && sp > maxSeekLen {
+		return 0, &MemioError{Op: "File.Seek", Err: fmt.Errorf("offset(%d) exceeds maximum(%d): %w", sp, maxSeekLen, fs.ErrInvalid)}
 	}
 	f.pos = int(sp)
 	// simulates creating "holes" in files
-	if f.pos > len(f.buf) {
-		f.buf = slices.Grow(f.buf, f.pos)[:f.pos]
+	if n := len(f.buf); f.pos > n {
+		f.own("File.Seek")
+		f.buf = slices.Grow(f.buf, f.pos-n)[:f.pos]
+		clear(f.buf[n:])
+		f.addHole(n, f.pos)
 	}
 	return sp, nil
 }
@@ -337,10 +1800,57 @@ func (f *File) Seek(offset int64, whence int) (int64, error) {
 //
 // It's equivalent to Seek(0, io.SeekStart) or Seek(0, 0)
 func (f *File) Rewind() *File {
+	f.mustBeOpen("File.Rewind")
 	f.pos = 0
 	return f
 }
 
+// BytesRead returns the total number of bytes consumed by reads since the File was created or ResetCounters was called
+//
+// Unlike Offset, it's not affected by Seek.
+func (f *File) BytesRead() int64 {
+	return f.nread
+}
+
+// BytesWritten returns the total number of bytes written since the File was created or ResetCounters was called
+//
+// Unlike Len, it's not affected by Seek or Truncate, and overwritten bytes are counted again.
+func (f *File) BytesWritten() int64 {
+	return f.nwritten
+}
+
+// ResetCounters sets the values returned by BytesRead and BytesWritten to 0
+func (f *File) ResetCounters() *File {
+	f.nread = 0
+	f.nwritten = 0
+	return f
+}
+
+// Mark returns the current position, to be passed to Restore
+func (f *File) Mark() int64 {
+	return int64(f.pos)
+}
+
+// Restore sets the current position back to mark, as returned by Mark
+//
+// If mark is not within [0, Len()], it's a no-op.
+func (f *File) Restore(mark int64) *File {
+	f.mustBeOpen("File.Restore")
+	if mark >= 0 && mark <= int64(len(f.buf)) {
+		f.pos = int(mark)
+	}
+	return f
+}
+
+// SetAppend enables or disables append mode, similar to opening a file with os.O_APPEND
+//
+// In append mode, all writes are added at the end of the internal buffer regardless of the current position.
+// Read and Seek work as usual, but the position after a write is always the end of the internal buffer.
+func (f *File) SetAppend(enable bool) *File {
+	f.appendMode = enable
+	return f
+}
+
 // Stat implements the fs.File.Stat interface
 //
 // It always returns itself
@@ -348,13 +1858,154 @@ func (f *File) Stat() (fs.FileInfo, error) {
 	return f, nil
 }
 
+// EnableChecksum enables a running CRC-32 checksum, using table, of the bytes written after the current end of the internal buffer
+//
+// The checksum is updated incrementally as the internal buffer grows,
+// so Checksum doesn't need to re-scan the whole buffer.
+// It covers bytes as they were when they were added to the end: overwriting bytes that were already added,
+// e.g. with PatchUint32 or after seeking backwards, doesn't change the checksum, so it no longer matches the content
+// until ResetChecksum is called. Bytes reserved with Reserve are covered as zeros, whether they're patched before or after later writes.
+// Bytes discarded by Compact, Truncate, Reset, etc. are still covered, so a stream can be checksummed while it's drained,
+// but the bytes of a buffer set by ResetBytes or UnmarshalBinary are not.
+// Passing nil disables it.
+func (f *File) EnableChecksum(table *crc32.Table) *File {
+	f.crcTable = table
+	return f.ResetChecksum()
+}
+
+// ResetChecksum resets the checksum enabled by EnableChecksum, to cover the bytes written after the current end of the internal buffer
+func (f *File) ResetChecksum() *File {
+	f.crc = 0
+	f.crcPos = len(f.buf)
+	return f
+}
+
+// Checksum returns the checksum enabled by EnableChecksum, or 0 if it's not enabled
+func (f *File) Checksum() uint32 {
+	f.updateChecksum()
+	return f.crc
+}
+
+// updateChecksum adds the bytes added since the last update to the checksum
+func (f *File) updateChecksum() {
+	if f.crcTable == nil {
+		return
+	}
+	if len(f.buf) > f.crcPos {
+		f.crc = crc32.Update(f.crc, f.crcTable, f.buf[f.crcPos:])
+	}
+	f.crcPos = len(f.buf)
+}
+
+// SetReadTee sets w to receive a copy of all bytes consumed by reads, like io.TeeReader
+//
+// It covers all read methods, including Read, ReadByte, ReadN and the typed reads, and WriteTo.
+// Bytes are written to w as they're consumed, so bytes that are read again after UnreadByte, Seek, etc. are written again.
+// If writing to w fails, the error is recorded, the tee is disabled, and the read proceeds as usual.
+// The error is returned by ReadTeeErr.
+// Passing nil disables the tee and clears the recorded error.
+func (f *File) SetReadTee(w io.Writer) *File {
+	f.readTee, f.readTeeErr = w, nil
+	return f
+}
+
+// ReadTeeErr returns the error, if any, that disabled the tee set by SetReadTee
+func (f *File) ReadTeeErr() error {
+	if f.readTeeErr == nil {
+		return nil
+	}
+	return &MemioError{Op: "File.SetReadTee", Err: f.readTeeErr}
+}
+
+// SetMaxSize limits the length of the internal buffer to n bytes, or removes the limit if n is not positive
+//
+// Methods that would grow the internal buffer beyond n return an error wrapping ErrMaxSizeExceeded instead of allocating.
+// Write, WriteString, WriteAt, ReadFrom, etc. write as many bytes as fit before returning the error.
+// The Print methods write nothing if their output doesn't fit,
+// and other methods that write, like WriteUint32 and Expand, panic with the error; use Try to return it instead.
+// If the internal buffer is already longer than n, it's not truncated, but it can't grow.
+func (f *File) SetMaxSize(n int) *File {
+	f.maxSize = n
+	return f
+}
+
+// sizeLimit returns the length the internal buffer can grow to, see SetMaxSize
+func (f *File) sizeLimit() int {
+	if f.maxSize <= 0 {
+		return math.MaxInt
+	}
+	return max(f.maxSize, len(f.buf))
+}
+
+// sizeRoom returns the number of bytes that can be written at the current position, see SetMaxSize
+//
+// In append mode, it's the number of bytes that can be written at the end.
+func (f *File) sizeRoom() int {
+	if f.appendMode {
+		return f.sizeLimit() - len(f.buf)
+	}
+	return f.sizeLimit() - f.pos
+}
+
+// SetReadOnly enables or disables read-only mode
+//
+// In read-only mode, the internal buffer is never modified.
+// Methods that write and return an error, like Write, WriteAt, ReadFrom and Seek past the end,
+// return an error wrapping fs.ErrPermission. The Print methods write nothing,
+// and other methods that write, like WriteUint32 and Expand, panic with the error; use Try to return it instead.
+// Reads, and seeks within the internal buffer work as usual.
+func (f *File) SetReadOnly(enable bool) *File {
+	f.readOnly = enable
+	return f
+}
+
+// SetStrictClose enables or disables strict close mode
+//
+// In strict close mode, Close invalidates the File, like os.File.Close:
+// subsequent reads, writes, seeks, unreads and calls to Close return an error wrapping fs.ErrClosed.
+// The Print methods write nothing, and other methods that read or write without returning an error,
+// like SplitN, WriteUint32, Expand, Truncate, Rewind and ResetBytes, panic with the error; use Try to return it instead.
+// It's intended to catch use-after-close bugs in code written against os.File.
+// Disabling it re-opens a closed File.
+func (f *File) SetStrictClose(enable bool) *File {
+	f.strictClose = enable
+	f.closed = f.closed && enable
+	return f
+}
+
 // Close implements the fs.File.Close interface
 //
-// It always returns nil
+// It's a no-op that always returns nil, unless strict close mode is enabled by SetStrictClose.
 func (f *File) Close() error {
+	if f.closed {
+		return &MemioError{Op: "File.Close", Err: fs.ErrClosed}
+	}
+	f.closed = f.strictClose
+	return nil
+}
+
+// Sync is like os.File.Sync, for compatibility with code that expects an *os.File
+//
+// There's no underlying storage to commit to, so it's a no-op that always returns nil.
+func (f *File) Sync() error {
+	return nil
+}
+
+// Flush is like bufio.Writer.Flush, for compatibility with code that flushes writers generically
+//
+// Writes are never buffered, so it's a no-op that always returns nil.
+func (f *File) Flush() error {
 	return nil
 }
 
+// ReadDir implements the fs.ReadDirFile interface
+//
+// A File is not a directory, so it always returns an error wrapping fs.ErrInvalid.
+// It allows a File to be passed to code that type-asserts fs.ReadDirFile.
+func (f *File) ReadDir(n int) ([]fs.DirEntry, error) {
+	return nil, &MemioError{Op: "File.ReadDir", Err: fmt.Errorf("not a directory: %w", fs.ErrInvalid)}
+}
+
 // Name implements the fs.FileInfo.Name interface
 //
 // It always returns ""
@@ -369,25 +2020,43 @@ func (f *File) Size() int64 {
 	return int64(len(f.buf))
 }
 
+// defaultMode is the mode returned by Mode if it's not set by SetMode
+const defaultMode fs.FileMode = 0o644
+
 // Mode implements the fs.FileInfo.Mode interface
 //
-// It always returns fs.ModeIrregular
+// It returns the mode set by SetMode, or a regular file with permissions 0644 by default
 func (f *File) Mode() fs.FileMode {
-	return fs.ModeIrregular
+	if !f.hasMode {
+		return defaultMode
+	}
+	return f.mode
+}
+
+// SetMode sets the mode returned by Mode
+func (f *File) SetMode(mode fs.FileMode) *File {
+	f.mode, f.hasMode = mode, true
+	return f
 }
 
 // ModTime implements the fs.FileInfo.ModTime interface
 //
-// It always returns time.Time{}
+// It returns the time set by SetModTime, or time.Time{} by default
 func (f *File) ModTime() time.Time {
-	return time.Time{}
+	return f.modTime
+}
+
+// SetModTime sets the time returned by ModTime
+func (f *File) SetModTime(t time.Time) *File {
+	f.modTime = t
+	return f
 }
 
 // IsDir implements the fs.FileInfo.IsDir interface
 //
-// It always returns false.
+// It reports whether Mode() is a directory, so it's false unless set by SetMode.
 func (f *File) IsDir() bool {
-	return false
+	return f.Mode().IsDir()
 }
 
 // Sys implements the fs.FileInfo.Sys interface
@@ -397,7 +2066,53 @@ func (f *File) Sys() any {
 	return nil
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler
+//
+// It encodes the internal offset as a uvarint, followed by the internal buffer
+func (f *File) MarshalBinary() ([]byte, error) {
+	p := make([]byte, 0, binary.MaxVarintLen64+len(f.buf))
+	p = binary.AppendUvarint(p, uint64(f.pos))
+	p = append(p, f.buf...)
+	return p, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+//
+// It decodes the format produced by MarshalBinary
+func (f *File) UnmarshalBinary(p []byte) error {
+	if f.closed {
+		return &MemioError{Op: "File.UnmarshalBinary", Err: fs.ErrClosed}
+	}
+	if f.readOnly {
+		return &MemioError{Op: "File.UnmarshalBinary", Err: fs.ErrPermission}
+	}
+	pos, n := binary.Uvarint(p)
+	if n <= 0 {
+		return &MemioError{Op: "File.UnmarshalBinary", Err: fmt.Errorf("invalid offset: %w", fs.ErrInvalid)}
+	}
+	p = p[n:]
+	if pos > uint64(len(p)) {
+		return &MemioError{Op: "File.UnmarshalBinary", Err: fmt.Errorf("offset(%d) > length(%d): %w", pos, len(p), fs.ErrInvalid)}
+	}
+	f.own("File.UnmarshalBinary")
+	f.updateChecksum()
+	f.buf = append(f.buf[:0], p...)
+	f.crcPos = len(f.buf)
+	f.pos = int(pos)
+	f.holes = nil
+	return nil
+}
+
+// NewReadOnly returns a new read-only File instance with the internal buffer set to s
+//
+// It's equivalent to NewFile(s).SetReadOnly(true), and protects s from being modified through the File.
+func NewReadOnly(s []byte) *File {
+	return NewFile(s).SetReadOnly(true)
+}
+
 // NewFile returns a new File instance with the internal buffer set to s
+//
+// Since s is owned by the caller, Put releases it instead of retaining it in the pool.
 func NewFile(s []byte) *File {
-	return &File{buf: s}
+	return &File{buf: s, borrowed: s != nil}
 }
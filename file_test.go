@@ -2,8 +2,23 @@ package memio
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
 	"io"
+	"io/fs"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"testing/iotest"
+	"time"
+	"unsafe"
 )
 
 func TestWriteSeeker(t *testing.T) {
@@ -92,4 +107,1898 @@ func TestTruncate(t *testing.T) {
 	if s := f.StringRef(); s != "world" {
 		t.Fatalf("Expected %q; Got %q", "world", s)
 	}
+
+	f.Truncate(10)
+	if f.Len() != 10 {
+		t.Fatalf("Expected Len() %d; Got %d", 10, f.Len())
+	}
+	if s := f.StringRef(); s != "world\x00\x00\x00\x00\x00" {
+		t.Fatalf("Expected %q; Got %q", "world\x00\x00\x00\x00\x00", s)
+	}
+}
+
+func TestReadLine(t *testing.T) {
+	f := NewFile([]byte("hello\r\nworld\n\nlast"))
+	for _, exp := range []string{"hello", "world", "", "last"} {
+		s, err := f.ReadLine()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(s); got != exp {
+			t.Fatalf("Expected %q; Got %q", exp, got)
+		}
+	}
+	if s, err := f.ReadLine(); s != nil || err != io.EOF {
+		t.Fatalf("Expected (nil, io.EOF); Got (%q, %v)", s, err)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	f := NewFile(make([]byte, 0, 64))
+	f.WriteString("hello world")
+	f.Seek(6, io.SeekStart)
+
+	c := cap(f.buf)
+	f.Compact()
+	if s := f.StringRef(); s != "world" {
+		t.Fatalf("Expected %q; Got %q", "world", s)
+	}
+	if f.Offset() != 0 {
+		t.Fatalf("Expected offset 0; Got %d", f.Offset())
+	}
+	if cap(f.buf) != c {
+		t.Fatalf("Expected capacity %d; Got %d", c, cap(f.buf))
+	}
+}
+
+func TestPatchFunc(t *testing.T) {
+	f := &File{}
+	f.WriteString("len=????;body")
+	err := f.PatchFunc(4, 4, func(s []byte) []byte {
+		return []byte("0004")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := f.StringRef(); s != "len=0004;body" {
+		t.Fatalf("Expected %q; Got %q", "len=0004;body", s)
+	}
+	if f.Offset() != int64(f.Len()) {
+		t.Fatalf("Expected offset %d; Got %d", f.Len(), f.Offset())
+	}
+
+	if err := f.PatchFunc(10, 4, func(s []byte) []byte { return s }); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+	}
+	if err := f.PatchFunc(0, 4, func(s []byte) []byte { return nil }); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+	}
+}
+
+func TestReadFull(t *testing.T) {
+	tests := []struct {
+		name string
+		buf  string
+		p    int
+		n    int
+		err  error
+	}{
+		{name: "empty p", buf: "", p: 0, n: 0, err: nil},
+		{name: "empty p with data", buf: "abc", p: 0, n: 0, err: nil},
+		{name: "exact fit", buf: "abc", p: 3, n: 3, err: nil},
+		{name: "short read", buf: "abc", p: 2, n: 2, err: nil},
+		{name: "underflow", buf: "abc", p: 4, n: 3, err: io.ErrUnexpectedEOF},
+		{name: "empty buffer", buf: "", p: 1, n: 0, err: io.ErrUnexpectedEOF},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := NewFile([]byte(tc.buf))
+			n, err := f.ReadFull(make([]byte, tc.p))
+			if n != tc.n {
+				t.Fatalf("Expected n=%d; Got %d", tc.n, n)
+			}
+			if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+				t.Fatalf("Expected error %v; Got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestSetAppend(t *testing.T) {
+	f := NewFile([]byte("hello")).SetAppend(true)
+	if _, err := f.Seek(1, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if c, _ := f.ReadByte(); c != 'e' {
+		t.Fatalf("Expected %q; Got %q", 'e', c)
+	}
+
+	f.WriteString(" world")
+	f.WriteByte('!')
+	if s := f.StringRef(); s != "hello world!" {
+		t.Fatalf("Expected %q; Got %q", "hello world!", s)
+	}
+	if f.Offset() != int64(f.Len()) {
+		t.Fatalf("Expected offset %d; Got %d", f.Len(), f.Offset())
+	}
+}
+
+func TestReadBytesLimit(t *testing.T) {
+	f := NewFile([]byte("abc,defghij,kl"))
+	s, err := f.ReadBytesLimit(',', 4)
+	if err != nil || string(s) != "abc" {
+		t.Fatalf("Expected (%q, nil); Got (%q, %v)", "abc", s, err)
+	}
+	s, err = f.ReadBytesLimit(',', 4)
+	if !errors.Is(err, ErrLimitExceeded) || string(s) != "defg" {
+		t.Fatalf("Expected (%q, ErrLimitExceeded); Got (%q, %v)", "defg", s, err)
+	}
+	f.Seek(12, io.SeekStart)
+	s, err = f.ReadBytesLimit(',', 4)
+	if !errors.Is(err, io.ErrUnexpectedEOF) || string(s) != "kl" {
+		t.Fatalf("Expected (%q, io.ErrUnexpectedEOF); Got (%q, %v)", "kl", s, err)
+	}
+}
+
+func TestResetBytes(t *testing.T) {
+	f := &File{}
+	for _, exp := range []string{"hello", "world", ""} {
+		s, err := io.ReadAll(f.ResetString(exp))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(s); got != exp {
+			t.Fatalf("Expected %q; Got %q", exp, got)
+		}
+		if _, err := f.Write([]byte("x")); !errors.Is(err, fs.ErrPermission) {
+			t.Fatalf("Expected ResetString to be read-only; Got %v", err)
+		}
+
+		s, err = io.ReadAll(f.ResetBytes([]byte(exp)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(s); got != exp {
+			t.Fatalf("Expected %q; Got %q", exp, got)
+		}
+		if _, err := f.Write([]byte("x")); err != nil {
+			t.Fatalf("Expected ResetBytes to be writable; Got %v", err)
+		}
+	}
+}
+
+func TestReadFromN(t *testing.T) {
+	r := bytes.NewReader([]byte("hello world"))
+	f := &File{}
+	n, err := f.ReadFromN(r, 5)
+	if err != nil || n != 5 {
+		t.Fatalf("Expected (5, nil); Got (%d, %v)", n, err)
+	}
+	if s := f.StringRef(); s != "hello" {
+		t.Fatalf("Expected %q; Got %q", "hello", s)
+	}
+	if r.Len() != 6 {
+		t.Fatalf("Expected 6 unread bytes; Got %d", r.Len())
+	}
+
+	n, err = f.ReadFromN(r, 10)
+	if !errors.Is(err, io.ErrUnexpectedEOF) || n != 6 {
+		t.Fatalf("Expected (6, io.ErrUnexpectedEOF); Got (%d, %v)", n, err)
+	}
+	if s := f.StringRef(); s != "hello world" {
+		t.Fatalf("Expected %q; Got %q", "hello world", s)
+	}
+	if f.Offset() != 11 {
+		t.Fatalf("Expected offset 11; Got %d", f.Offset())
+	}
+
+	f = &File{}
+	f.Seek(10, io.SeekStart)
+	f.Seek(0, io.SeekStart)
+	f.ReadFromN(strings.NewReader("ab"), 8)
+	if !f.IsHole(5) || f.IsHole(1) || f.DataLen() != 2 || f.Len() != 10 {
+		t.Fatalf("Expected only [0:2] to be written; Got IsHole(5)=%v, IsHole(1)=%v, DataLen()=%d, Len()=%d", f.IsHole(5), f.IsHole(1), f.DataLen(), f.Len())
+	}
+}
+
+func TestWriteToEmpty(t *testing.T) {
+	buf := &bytes.Buffer{}
+	n, err := io.Copy(buf, &File{})
+	if err != nil || n != 0 {
+		t.Fatalf("Expected (0, nil); Got (%d, %v)", n, err)
+	}
+
+	f := NewFile([]byte("hello"))
+	f.Seek(0, io.SeekEnd)
+	n, err = f.WriteTo(buf)
+	if err != nil || n != 0 {
+		t.Fatalf("Expected (0, nil); Got (%d, %v)", n, err)
+	}
+}
+
+func TestRecords(t *testing.T) {
+	f := NewFile([]byte("a,bc,,d"))
+	got := []string{}
+	for p, err := range f.Records(',') {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(p))
+	}
+	if exp := []string{"a", "bc", "", "d"}; !slices.Equal(got, exp) {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
+
+func TestReadN(t *testing.T) {
+	f := NewFile([]byte("hello world"))
+	s, err := f.ReadN(5)
+	if err != nil || string(s) != "hello" {
+		t.Fatalf("Expected (%q, nil); Got (%q, %v)", "hello", s, err)
+	}
+	if &s[0] != &f.Bytes()[0] {
+		t.Fatalf("Expected a slice of the internal buffer")
+	}
+	s, err = f.ReadN(10)
+	if !errors.Is(err, io.ErrUnexpectedEOF) || string(s) != " world" {
+		t.Fatalf("Expected (%q, io.ErrUnexpectedEOF); Got (%q, %v)", " world", s, err)
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	src := NewFile([]byte("hello world"))
+	src.Seek(6, io.SeekStart)
+
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(src); err != nil {
+		t.Fatal(err)
+	}
+	dst := &File{}
+	if err := gob.NewDecoder(buf).Decode(dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst.StringRef() != "hello world" || dst.Offset() != 6 {
+		t.Fatalf("Expected (%q, 6); Got (%q, %d)", "hello world", dst.StringRef(), dst.Offset())
+	}
+
+	if err := dst.UnmarshalBinary([]byte{5, 'a', 'b'}); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+	}
+}
+
+func TestReadStringInc(t *testing.T) {
+	f := NewFile([]byte("hello\nworld"))
+	s, err := f.ReadStringInc('\n')
+	if err != nil || s != "hello\n" {
+		t.Fatalf("Expected (%q, nil); Got (%q, %v)", "hello\n", s, err)
+	}
+	p, err := f.ReadBytesInc('\n')
+	if err != io.EOF || string(p) != "world" {
+		t.Fatalf("Expected (%q, io.EOF); Got (%q, %v)", "world", p, err)
+	}
+	s, err = f.ReadStringInc('\n')
+	if err != io.EOF || s != "" {
+		t.Fatalf("Expected (%q, io.EOF); Got (%q, %v)", "", s, err)
+	}
+}
+
+func TestTrim(t *testing.T) {
+	f := NewFile(make([]byte, 0, 1024))
+	f.WriteString("hello")
+
+	f.TrimTo(2048)
+	if exp, got := 1024, cap(f.Bytes()); got != exp {
+		t.Fatalf("Expected capacity %d; Got %d", exp, got)
+	}
+	f.TrimTo(64)
+	if exp, got := 64, cap(f.Bytes()); got != exp {
+		t.Fatalf("Expected capacity %d; Got %d", exp, got)
+	}
+	f.Trim()
+	if exp, got := 5, cap(f.Bytes()); got != exp {
+		t.Fatalf("Expected capacity %d; Got %d", exp, got)
+	}
+	if s := f.StringRef(); s != "hello" {
+		t.Fatalf("Expected %q; Got %q", "hello", s)
+	}
+}
+
+func TestCopyAllTo(t *testing.T) {
+	f := NewFile([]byte("hello world"))
+	f.Seek(6, io.SeekStart)
+
+	h := crc32.NewIEEE()
+	n, err := f.CopyAllTo(h)
+	if err != nil || n != 11 {
+		t.Fatalf("Expected (11, nil); Got (%d, %v)", n, err)
+	}
+	if exp, got := crc32.ChecksumIEEE([]byte("hello world")), h.Sum32(); got != exp {
+		t.Fatalf("Expected checksum %x; Got %x", exp, got)
+	}
+	if f.Offset() != 6 {
+		t.Fatalf("Expected offset 6; Got %d", f.Offset())
+	}
+}
+
+func TestInt8(t *testing.T) {
+	f := &File{}
+	f.WriteUint8(200)
+	f.WriteInt8(-5)
+	f.Rewind()
+
+	if n, err := f.ReadUint8(); err != nil || n != 200 {
+		t.Fatalf("Expected (200, nil); Got (%d, %v)", n, err)
+	}
+	if n, err := f.ReadInt8(); err != nil || n != -5 {
+		t.Fatalf("Expected (-5, nil); Got (%d, %v)", n, err)
+	}
+	if _, err := f.ReadInt8(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected io.ErrUnexpectedEOF; Got %v", err)
+	}
+}
+
+func TestUint24(t *testing.T) {
+	f := &File{}
+	f.WriteUint24(binary.BigEndian, 0xff010203)
+	f.WriteUint24(binary.LittleEndian, 0x040506)
+	if exp, got := "\x01\x02\x03\x06\x05\x04", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	f.Rewind()
+	if n, err := f.ReadUint24(binary.BigEndian); err != nil || n != 0x010203 {
+		t.Fatalf("Expected (0x010203, nil); Got (%#x, %v)", n, err)
+	}
+	if n, err := f.ReadUint24(binary.LittleEndian); err != nil || n != 0x040506 {
+		t.Fatalf("Expected (0x040506, nil); Got (%#x, %v)", n, err)
+	}
+	if _, err := f.ReadUint24(binary.BigEndian); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected io.ErrUnexpectedEOF; Got %v", err)
+	}
+}
+
+func TestExpandZero(t *testing.T) {
+	f := &File{}
+	f.WriteString("garbage!")
+	f.Reset()
+
+	s := f.ExpandZero(8)
+	copy(s, "hello")
+	if exp, got := "hello\x00\x00\x00", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
+
+func TestSection(t *testing.T) {
+	f := NewFile([]byte("hello world"))
+	sec := f.Section(6, 3)
+	s, _ := io.ReadAll(sec)
+	if exp, got := "wor", string(s); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	sec.Rewind().WriteString("W")
+	if exp, got := "hello World", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	sec.Seek(0, io.SeekEnd)
+	sec.WriteString("!!")
+	if exp, got := "hello World", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if exp, got := "Wor!!", sec.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
+
+func TestTail(t *testing.T) {
+	f := NewFile([]byte("hello world"))
+	if exp, got := "world", string(f.Tail(5)); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if exp, got := "hello world", string(f.Tail(100)); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if f.Offset() != 0 {
+		t.Fatalf("Expected offset 0; Got %d", f.Offset())
+	}
+}
+
+func FuzzSeek(f *testing.F) {
+	f.Add(int64(0), io.SeekStart)
+	f.Add(int64(-1), io.SeekEnd)
+	f.Add(int64(math.MaxInt64), io.SeekCurrent)
+	f.Add(int64(math.MaxInt64), io.SeekEnd)
+	f.Add(int64(math.MinInt64), io.SeekEnd)
+	f.Add(int64(maxSeekLen+1), io.SeekStart)
+	f.Add(int64(1), 3)
+	f.Fuzz(func(t *testing.T, offset int64, whence int) {
+		if offset > 1<<20 && offset <= maxSeekLen {
+			// valid, but allocates too much memory to fuzz
+			return
+		}
+		f := NewFile([]byte("hello"))
+		f.Seek(2, io.SeekStart)
+		n, err := f.Seek(offset, whence)
+		if err != nil {
+			if !errors.Is(err, fs.ErrInvalid) {
+				t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+			}
+			return
+		}
+		if n != f.Offset() || f.Offset() < 0 || f.Offset() > int64(f.Len()) {
+			t.Fatalf("Invalid offset %d (%d) for length %d", n, f.Offset(), f.Len())
+		}
+	})
+}
+
+func BenchmarkReadFrom(b *testing.B) {
+	src := bytes.Repeat([]byte("0123456789abcdef"), 1<<20)
+	b.Run("Unsized", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(src)))
+		for i := 0; i < b.N; i++ {
+			f := &File{}
+			// hide bytes.Reader.Len, so ReadFrom can't pre-grow
+			if _, err := f.ReadFrom(struct{ io.Reader }{bytes.NewReader(src)}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Sized", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(src)))
+		for i := 0; i < b.N; i++ {
+			f := &File{}
+			if _, err := f.ReadFrom(bytes.NewReader(src)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestReadFromSizeHint(t *testing.T) {
+	src := bytes.Repeat([]byte("0123456789abcdef"), 1<<12)
+	f := &File{}
+	f.WriteString("head:")
+	n, err := f.ReadFrom(bytes.NewReader(src))
+	if err != nil || n != int64(len(src)) {
+		t.Fatalf("Expected (%d, nil); Got (%d, %v)", len(src), n, err)
+	}
+	if exp, got := "head:"+string(src), f.StringRef(); got != exp {
+		t.Fatalf("Expected %d bytes; Got %d", len(exp), len(got))
+	}
+
+	if raceEnabled {
+		t.Skip("the race detector adds allocations to slices.Grow")
+	}
+	f, r := &File{}, bytes.NewReader(nil)
+	allocs := testing.AllocsPerRun(10, func() {
+		f.Clear()
+		r.Reset(src)
+		f.ReadFrom(r)
+	})
+	// the File and reader are reused, so the only allocation is the internal buffer, which is grown once up front
+	if allocs > 1 {
+		t.Fatalf("Expected at most 1 allocation; Got %v", allocs)
+	}
+}
+
+func TestReadFrom(t *testing.T) {
+	src := bytes.Repeat([]byte("0123456789abcdef"), 1<<12)
+	f := &File{}
+	f.WriteString("head:")
+	n, err := f.ReadFrom(bytes.NewReader(src))
+	if err != nil || n != int64(len(src)) {
+		t.Fatalf("Expected (%d, nil); Got (%d, %v)", len(src), n, err)
+	}
+	if exp, got := "head:"+string(src), f.StringRef(); got != exp {
+		t.Fatalf("Expected %d bytes; Got %d", len(exp), len(got))
+	}
+	if f.Offset() != int64(f.Len()) {
+		t.Fatalf("Expected offset %d; Got %d", f.Len(), f.Offset())
+	}
+}
+
+func TestWriteRune(t *testing.T) {
+	f := &File{}
+	for _, c := range []struct {
+		r rune
+		n int
+	}{{'a', 1}, {'é', 2}, {'世', 3}, {0xD800, 3}, {-1, 3}} {
+		if n, err := f.WriteRune(c.r); err != nil || n != c.n {
+			t.Fatalf("Expected (%d, nil); Got (%d, %v)", c.n, n, err)
+		}
+	}
+	if exp, got := "aé世��", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
+
+func TestMarkRestore(t *testing.T) {
+	f := NewFile([]byte("hello world"))
+	f.ReadN(6)
+	m := f.Mark()
+	if _, err := f.ReadN(5); err != nil {
+		t.Fatal(err)
+	}
+	if exp, got := int64(6), f.Restore(m).Offset(); got != exp {
+		t.Fatalf("Expected offset %d; Got %d", exp, got)
+	}
+	if exp, got := int64(6), f.Restore(100).Restore(-1).Offset(); got != exp {
+		t.Fatalf("Expected offset %d; Got %d", exp, got)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	a := NewFile([]byte("abc"))
+	b := NewFile([]byte("abd"))
+	b.ReadByte()
+	var z *File
+
+	if !a.Equal(NewFile([]byte("abc"))) || a.Equal(b) || !a.EqualBytes([]byte("abc")) {
+		t.Fatalf("Equal and EqualBytes should compare content")
+	}
+	if !z.Equal(&File{}) || !z.EqualBytes(nil) || a.Equal(z) || z.Equal(a) {
+		t.Fatalf("nil should equal empty")
+	}
+	if a.Compare(b) != -1 || b.Compare(a) != 1 || a.Compare(a) != 0 || z.Compare(a) != -1 {
+		t.Fatalf("Compare should order by content")
+	}
+}
+
+func TestDrainTo(t *testing.T) {
+	f := NewFile([]byte("hello world"))
+	f.Seek(6, io.SeekStart)
+	buf := &bytes.Buffer{}
+	n, err := f.DrainTo(buf)
+	if err != nil || n != 5 || buf.String() != "world" {
+		t.Fatalf("Expected (5, nil, %q); Got (%d, %v, %q)", "world", n, err, buf.String())
+	}
+	if f.Len() != 0 || f.Offset() != 0 {
+		t.Fatalf("Expected an empty File; Got Len()=%d, Offset()=%d", f.Len(), f.Offset())
+	}
+
+	f = NewFile([]byte("hello"))
+	_, err = f.DrainTo(&chunkWriter{n: 0})
+	if exp, got := "File.DrainTo: short write", fmt.Sprint(err); got != exp || !errors.Is(err, io.ErrShortWrite) {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
+
+func TestAvailableBuffer(t *testing.T) {
+	f := NewFile(make([]byte, 0, 64))
+	f.WriteString("n=")
+	if exp, got := 62, f.Available(); got != exp {
+		t.Fatalf("Expected %d; Got %d", exp, got)
+	}
+
+	b := strconv.AppendInt(f.AvailableBuffer(), 1234, 10)
+	f.Write(b)
+	if exp, got := "n=1234", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if exp, got := 64, cap(f.Bytes()); got != exp {
+		t.Fatalf("Expected capacity %d; Got %d", exp, got)
+	}
+}
+
+func TestPrintRuneOverwrite(t *testing.T) {
+	f := NewFile([]byte("abcdefgh"))
+	f.Seek(2, io.SeekStart)
+	f.PrintRune('世').PrintRune('x')
+	if exp, got := "ab世xgh", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if exp, got := int64(6), f.Offset(); got != exp {
+		t.Fatalf("Expected offset %d; Got %d", exp, got)
+	}
+
+	f.Seek(0, io.SeekEnd)
+	f.PrintRune('é')
+	if exp, got := "ab世xghé", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
+
+func TestExpandGrowPanics(t *testing.T) {
+	expectPanic := func(msg string, fn func()) {
+		t.Helper()
+		defer func() {
+			t.Helper()
+			if got := recover(); got != msg {
+				t.Fatalf("Expected panic %q; Got %v", msg, got)
+			}
+		}()
+		fn()
+	}
+	f := NewFile([]byte("hello"))
+	f.Seek(1, io.SeekStart)
+	expectPanic("memio: Expand: negative count", func() { f.Expand(-1) })
+	expectPanic("memio: Grow: negative count", func() { f.Grow(-1) })
+	expectPanic("memio: Expand: count too large", func() { f.Expand(math.MaxInt) })
+	expectPanic("memio: Grow: count too large", func() { f.Grow(math.MaxInt) })
+}
+
+func TestRemaining(t *testing.T) {
+	f := NewFile([]byte("hello world"))
+	f.Seek(6, io.SeekStart)
+	if exp, got := "world", string(f.Remaining()); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if exp, got := 5, f.RemainingLen(); got != exp {
+		t.Fatalf("Expected %d; Got %d", exp, got)
+	}
+	if f.Offset() != 6 {
+		t.Fatalf("Expected offset 6; Got %d", f.Offset())
+	}
+}
+
+func TestWriteStringAt(t *testing.T) {
+	f := NewFile([]byte("name:     ;"))
+	if n, err := f.WriteStringAt("memio", 5); err != nil || n != 5 {
+		t.Fatalf("Expected (5, nil); Got (%d, %v)", n, err)
+	}
+	if n, err := f.WriteStringAt("!", 13); err != nil || n != 1 {
+		t.Fatalf("Expected (1, nil); Got (%d, %v)", n, err)
+	}
+	if exp, got := "name:memio;\x00\x00!", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if f.Offset() != 0 {
+		t.Fatalf("Expected offset 0; Got %d", f.Offset())
+	}
+	if _, err := f.WriteStringAt("x", -1); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+	}
+	if _, err := f.WriteAt([]byte("x"), math.MaxInt64); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+	}
+}
+
+func TestCounters(t *testing.T) {
+	f := &File{}
+	f.WriteString("hello")
+	f.WriteUint16(binary.BigEndian, 1)
+	f.Rewind()
+	f.WriteByte('H')
+	if exp, got := int64(8), f.BytesWritten(); got != exp {
+		t.Fatalf("Expected %d bytes written; Got %d", exp, got)
+	}
+
+	f.ReadN(3)
+	f.Rewind()
+	io.ReadAll(f)
+	f.ReadByte()
+	if exp, got := int64(10), f.BytesRead(); got != exp {
+		t.Fatalf("Expected %d bytes read; Got %d", exp, got)
+	}
+
+	f.ResetCounters()
+	if f.BytesRead() != 0 || f.BytesWritten() != 0 {
+		t.Fatalf("Expected counters to be reset; Got (%d, %d)", f.BytesRead(), f.BytesWritten())
+	}
+}
+
+func TestClear(t *testing.T) {
+	f := &File{}
+	f.WriteString("hello")
+	if f.Reset(); cap(f.Bytes()) == 0 {
+		t.Fatalf("Expected Reset to retain capacity")
+	}
+	f.WriteString("hello")
+	if f.Clear(); f.Bytes() != nil || f.Offset() != 0 {
+		t.Fatalf("Expected Clear to release the buffer; Got (%q, %d)", f.Bytes(), f.Offset())
+	}
+}
+
+func TestUnreadByte(t *testing.T) {
+	f := NewFile([]byte("abc"))
+	if err := f.UnreadByte(); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+	}
+
+	f.ReadByte()
+	c, _ := f.ReadByte()
+	if err := f.UnreadByte(); err != nil {
+		t.Fatal(err)
+	}
+	if d, _ := f.ReadByte(); d != c {
+		t.Fatalf("Expected %q; Got %q", c, d)
+	}
+
+	f.Rewind()
+	f.ReadByte()
+	f.ReadN(1)
+	if err := f.UnreadByte(); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+	}
+
+	f.Rewind()
+	f.ReadByte()
+	f.UnreadByte()
+	if err := f.UnreadByte(); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+	}
+}
+
+func TestStreamFrom(t *testing.T) {
+	f := &File{}
+	chunks := []string{}
+	n, err := f.StreamFrom(strings.NewReader("hello world"), 4, func(p []byte) error {
+		chunks = append(chunks, string(p))
+		return nil
+	})
+	if err != nil || n != 11 {
+		t.Fatalf("Expected (11, nil); Got (%d, %v)", n, err)
+	}
+	if exp := []string{"hell", "o wo", "rld"}; !slices.Equal(chunks, exp) {
+		t.Fatalf("Expected %q; Got %q", exp, chunks)
+	}
+	if cap(f.Bytes()) > 8 {
+		t.Fatalf("Expected the buffer to be reused; Got capacity %d", cap(f.Bytes()))
+	}
+
+	stop := errors.New("stop")
+	n, err = f.StreamFrom(strings.NewReader("hello world"), 4, func(p []byte) error {
+		return stop
+	})
+	if err != stop || n != 4 {
+		t.Fatalf("Expected (4, stop); Got (%d, %v)", n, err)
+	}
+}
+
+func TestSeekHole(t *testing.T) {
+	dirty := bytes.Repeat([]byte{'?'}, 32)
+	f := NewFile(dirty[:0])
+	f.WriteString("abc")
+	f.Seek(10, io.SeekStart)
+	f.WriteString("x")
+	if exp, got := "abc\x00\x00\x00\x00\x00\x00\x00x", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
+
+func TestWriteFill(t *testing.T) {
+	f := &File{}
+	f.WriteString("id")
+	f.WriteFill(' ', 3).WriteFill('x', 0)
+	f.WriteString("name")
+	f.WritePad('.', 12).WritePad('!', 4)
+	if exp, got := "id   name...", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
+
+// chunkWriter writes at most n bytes per call, without returning an error
+type chunkWriter struct {
+	n   int
+	buf bytes.Buffer
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p[:min(len(p), w.n)])
+}
+
+func TestWriteToPartial(t *testing.T) {
+	f := NewFile([]byte("hello world"))
+	f.Seek(1, io.SeekStart)
+	w := &chunkWriter{n: 3}
+	n, err := f.WriteTo(w)
+	if err != nil || n != 10 || w.buf.String() != "ello world" {
+		t.Fatalf("Expected (10, nil, %q); Got (%d, %v, %q)", "ello world", n, err, w.buf.String())
+	}
+	if f.Offset() != 11 {
+		t.Fatalf("Expected offset 11; Got %d", f.Offset())
+	}
+
+	f.Rewind()
+	n, err = f.WriteTo(&chunkWriter{n: 0})
+	if !errors.Is(err, io.ErrShortWrite) || n != 0 {
+		t.Fatalf("Expected (0, io.ErrShortWrite); Got (%d, %v)", n, err)
+	}
+}
+
+// recordingWriter records the size of each call to Write
+type recordingWriter struct {
+	writes []int
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, len(p))
+	return len(p), nil
+}
+
+func TestCopyUsesWriteTo(t *testing.T) {
+	// larger than io.Copy's 32KiB buffer, so the generic loop would need several writes
+	f := NewFile(bytes.Repeat([]byte("x"), 100<<10))
+	f.Seek(10, io.SeekStart)
+	w := &recordingWriter{}
+	n, err := io.Copy(w, f)
+	if err != nil || n != 100<<10-10 {
+		t.Fatalf("Expected (%d, nil); Got (%d, %v)", 100<<10-10, n, err)
+	}
+	if exp := []int{100<<10 - 10}; !slices.Equal(w.writes, exp) {
+		t.Fatalf("Expected writes %v; Got %v", exp, w.writes)
+	}
+	if f.RemainingLen() != 0 {
+		t.Fatalf("Expected no bytes remaining; Got %d", f.RemainingLen())
+	}
+}
+
+func TestWriteTracked(t *testing.T) {
+	f := NewFile(make([]byte, 0, 4))
+	if _, grew, _ := f.WriteTracked([]byte("abcd")); grew {
+		t.Fatalf("Expected no reallocation")
+	}
+	if _, grew, _ := f.WriteTracked([]byte("e")); !grew {
+		t.Fatalf("Expected a reallocation")
+	}
+}
+
+func TestSlice(t *testing.T) {
+	f := NewFile([]byte("hello world"))
+	if s, err := f.Slice(6, 11); err != nil || string(s) != "world" {
+		t.Fatalf("Expected (%q, nil); Got (%q, %v)", "world", s, err)
+	}
+	s, err := f.SliceCopy(0, 5)
+	if err != nil || string(s) != "hello" {
+		t.Fatalf("Expected (%q, nil); Got (%q, %v)", "hello", s, err)
+	}
+	s[0] = 'H'
+	if f.StringRef() != "hello world" {
+		t.Fatalf("Expected SliceCopy to return a copy")
+	}
+	for _, r := range [][2]int64{{-1, 2}, {3, 2}, {0, 12}} {
+		if _, err := f.Slice(r[0], r[1]); !errors.Is(err, fs.ErrInvalid) {
+			t.Fatalf("Expected fs.ErrInvalid for %v; Got %v", r, err)
+		}
+	}
+}
+
+func TestGrowCap(t *testing.T) {
+	f := &File{}
+	f.WriteString("hello")
+	f.Grow(1024)
+	c := f.Cap()
+	if c < 5+1024 {
+		t.Fatalf("Expected capacity >= %d; Got %d", 5+1024, c)
+	}
+	f.Grow(1024)
+	if got := f.Cap(); got != c {
+		t.Fatalf("Expected capacity %d after repeated Grow; Got %d", c, got)
+	}
+	for range 1024 {
+		f.WriteByte('x')
+	}
+	if got := f.Cap(); got != c {
+		t.Fatalf("Expected capacity %d after writes; Got %d", c, got)
+	}
+	if exp, got := f.Cap()-f.Len(), f.Available(); got != exp {
+		t.Fatalf("Expected %d; Got %d", exp, got)
+	}
+
+	f = NewFile(make([]byte, 100, 100))
+	f.Grow(10)
+	if exp, got := 100, f.Cap(); got != exp {
+		t.Fatalf("Expected capacity %d when overwriting; Got %d", exp, got)
+	}
+}
+
+func TestReadUntilAny(t *testing.T) {
+	f := NewFile([]byte("a=1;b\xff2"))
+	tests := []struct {
+		data  string
+		delim byte
+	}{
+		{"a", '='},
+		{"1", ';'},
+		{"b", 0xff},
+	}
+	for _, c := range tests {
+		data, delim, err := f.ReadUntilAny([]byte{';', '=', 0xff})
+		if err != nil || string(data) != c.data || delim != c.delim {
+			t.Fatalf("Expected (%q, %q, nil); Got (%q, %q, %v)", c.data, c.delim, data, delim, err)
+		}
+	}
+	data, _, err := f.ReadUntilAny([]byte{';', '='})
+	if !errors.Is(err, io.ErrUnexpectedEOF) || string(data) != "2" {
+		t.Fatalf("Expected (%q, io.ErrUnexpectedEOF); Got (%q, %v)", "2", data, err)
+	}
+	if exp, got := 0, f.RemainingLen(); got != exp {
+		t.Fatalf("Expected %d remaining; Got %d", exp, got)
+	}
+}
+
+func TestPrintNumbers(t *testing.T) {
+	f := &File{}
+	ints := []int64{0, -1, 42, math.MinInt64, math.MaxInt64}
+	uints := []uint64{0, 7, math.MaxUint64}
+	floats := []float64{0, -1.5, 1e21, 3.141592653589793, math.Inf(1), math.NaN()}
+	exp := &File{}
+	for _, n := range ints {
+		f.PrintInt(n).PrintRune(' ')
+		fmt.Fprintf(exp, "%d ", n)
+	}
+	for _, n := range uints {
+		f.PrintUint(n).PrintRune(' ')
+		fmt.Fprintf(exp, "%d ", n)
+	}
+	for _, v := range floats {
+		f.PrintFloat(v, 'g', -1).PrintRune(' ')
+		fmt.Fprintf(exp, "%g ", v)
+	}
+	if exp, got := exp.StringRef(), f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
+
+func BenchmarkPrintInt(b *testing.B) {
+	b.Run("PrintInt", func(b *testing.B) {
+		b.ReportAllocs()
+		f := &File{}
+		for i := 0; i < b.N; i++ {
+			f.Reset().PrintInt(int64(i))
+		}
+	})
+	b.Run("Fprintf", func(b *testing.B) {
+		b.ReportAllocs()
+		f := &File{}
+		for i := 0; i < b.N; i++ {
+			f.Reset()
+			fmt.Fprintf(f, "%d", i)
+		}
+	})
+}
+
+func BenchmarkPrintFloat(b *testing.B) {
+	b.Run("PrintFloat", func(b *testing.B) {
+		b.ReportAllocs()
+		f := &File{}
+		for i := 0; i < b.N; i++ {
+			f.Reset().PrintFloat(float64(i)/3, 'g', -1)
+		}
+	})
+	b.Run("Fprintf", func(b *testing.B) {
+		b.ReportAllocs()
+		f := &File{}
+		for i := 0; i < b.N; i++ {
+			f.Reset()
+			fmt.Fprintf(f, "%g", float64(i)/3)
+		}
+	})
+}
+
+func TestStrictClose(t *testing.T) {
+	f := NewFile([]byte("hello"))
+	if err := f.Close(); err != nil {
+		t.Fatalf("Expected nil; Got %v", err)
+	}
+	if _, err := f.Write([]byte("!")); err != nil {
+		t.Fatalf("Expected non-strict Close to be a no-op; Got %v", err)
+	}
+
+	f.SetStrictClose(true).Rewind()
+	if err := f.Close(); err != nil {
+		t.Fatalf("Expected nil; Got %v", err)
+	}
+	ops := map[string]func() error{
+		"Read":     func() error { _, err := f.Read(make([]byte, 1)); return err },
+		"ReadByte": func() error { _, err := f.ReadByte(); return err },
+		"Write":    func() error { _, err := f.Write([]byte("x")); return err },
+		"WriteAt":  func() error { _, err := f.WriteAt([]byte("x"), 0); return err },
+		"Seek":     func() error { _, err := f.Seek(0, io.SeekStart); return err },
+		"WriteTo":  func() error { _, err := f.WriteTo(io.Discard); return err },
+		"Close":    f.Close,
+
+		"ReadUint32":    func() error { _, err := f.ReadUint32(binary.BigEndian); return err },
+		"ReadUint16LE":  func() error { _, err := f.ReadUint16LE(); return err },
+		"ReadN":         func() error { _, err := f.ReadN(1); return err },
+		"ReadBytes":     func() error { _, err := f.ReadBytes('l'); return err },
+		"ReadBytesInc":  func() error { _, err := f.ReadBytesInc('l'); return err },
+		"ReadLine":      func() error { _, err := f.ReadLine(); return err },
+		"ReadCString":   func() error { _, err := f.ReadCString(8); return err },
+		"ReadStruct":    func() error { v := struct{ A uint16 }{}; return f.ReadStruct(binary.NativeEndian, &v) },
+		"ReadFrame":     func() error { _, err := f.ReadFrame(binary.BigEndian, 8); return err },
+		"ReadVarString": func() error { _, err := f.ReadVarString(); return err },
+		"ReadUint32Slice": func() error {
+			_, err := f.ReadUint32Slice(binary.BigEndian, make([]uint32, 1))
+			return err
+		},
+		"ReadBits":     func() error { _, err := NewBitReader(f).ReadBits(1); return err },
+		"AlignRead":    func() error { return f.AlignRead(4) },
+		"WriteCString": func() error { return f.WriteCString("x") },
+		"PatchUint32":  func() error { return f.PatchUint32(0, binary.BigEndian, 1) },
+		"WriteLenPrefixed": func() error {
+			return f.WriteLenPrefixed(binary.BigEndian, 2, []byte("x"))
+		},
+		"UnmarshalJSON": func() error { return f.UnmarshalJSON([]byte(`"eHg="`)) },
+		"UnreadN":       func() error { return f.UnreadN(0) },
+		"UnreadByte":    func() error { return f.UnreadByte() },
+	}
+	for name, op := range ops {
+		if err := op(); !errors.Is(err, fs.ErrClosed) {
+			t.Fatalf("%s: Expected fs.ErrClosed; Got %v", name, err)
+		}
+	}
+	for name, op := range map[string]func(){
+		"WriteUint32": func() { f.WriteUint32(binary.BigEndian, 1) },
+		"SplitN":      func() { f.SplitN('l', 0) },
+		"ResetBytes":  func() { f.ResetBytes(nil) },
+		"ResetString": func() { f.ResetString("") },
+		"Truncate":    func() { f.Truncate(1) },
+		"Reset":       func() { f.Reset() },
+		"Clear":       func() { f.Clear() },
+		"Rewind":      func() { f.Rewind() },
+		"Restore":     func() { f.Restore(0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%s: Expected a panic", name)
+				}
+			}()
+			op()
+		}()
+	}
+	f.PrintInt(1).PrintString("x").PrintLine("y")
+	if exp, got := "!ello", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if f.Offset() != 0 {
+		t.Fatalf("Expected offset 0; Got %d", f.Offset())
+	}
+
+	f.SetStrictClose(false)
+	if _, err := f.ReadByte(); err != nil {
+		t.Fatalf("Expected disabling strict close to re-open; Got %v", err)
+	}
+}
+
+func TestReadTee(t *testing.T) {
+	tee := &File{}
+	f := NewFile([]byte("\x00\x2aab\ncd-rest")).SetReadTee(tee)
+	f.ReadUint16(binary.BigEndian)
+	f.ReadByte()
+	f.UnreadByte()
+	f.ReadLine()
+	f.ReadN(2)
+	p := make([]byte, 1)
+	f.Read(p)
+	if exp, got := "\x00\x2aaab\ncd-", tee.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	f.SetReadTee(nil)
+	f.ReadByte()
+	if exp, got := "\x00\x2aaab\ncd-", tee.StringRef(); got != exp {
+		t.Fatalf("Expected %q after disabling the tee; Got %q", exp, got)
+	}
+
+	f.SetReadTee(&chunkWriter{n: 0})
+	if _, err := f.ReadN(2); err != nil {
+		t.Fatalf("Expected the read to succeed; Got %v", err)
+	}
+	if err := f.ReadTeeErr(); !errors.Is(err, io.ErrShortWrite) {
+		t.Fatalf("Expected io.ErrShortWrite; Got %v", err)
+	}
+}
+
+func TestCString(t *testing.T) {
+	f := &File{}
+	if err := f.WriteCString("hello"); err != nil {
+		t.Fatal(err)
+	}
+	f.WriteCString("")
+	if err := f.WriteCString("a\x00b"); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+	}
+	f.WriteString("0123456789")
+	if exp, got := "hello\x00\x000123456789", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	f.Rewind()
+	if s, err := f.ReadCString(6); err != nil || s != "hello" {
+		t.Fatalf("Expected (%q, nil); Got (%q, %v)", "hello", s, err)
+	}
+	if s, err := f.ReadCString(1); err != nil || s != "" {
+		t.Fatalf("Expected (%q, nil); Got (%q, %v)", "", s, err)
+	}
+	if s, err := f.ReadCString(4); !errors.Is(err, ErrLimitExceeded) || s != "0123" {
+		t.Fatalf("Expected (%q, ErrLimitExceeded); Got (%q, %v)", "0123", s, err)
+	}
+	if s, err := f.ReadCString(100); !errors.Is(err, io.ErrUnexpectedEOF) || s != "456789" {
+		t.Fatalf("Expected (%q, io.ErrUnexpectedEOF); Got (%q, %v)", "456789", s, err)
+	}
+}
+
+func TestAlign(t *testing.T) {
+	f := &File{}
+	f.WriteString("abc")
+	f.Align(4, '.').WriteString("d")
+	f.Align(4, 0).Align(4, 0)
+	if exp, got := "abc.d\x00\x00\x00", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	f.Seek(1, io.SeekStart)
+	if err := f.AlignRead(4); err != nil || f.Offset() != 4 {
+		t.Fatalf("Expected (4, nil); Got (%d, %v)", f.Offset(), err)
+	}
+	if err := f.AlignRead(4); err != nil || f.Offset() != 4 {
+		t.Fatalf("Expected (4, nil); Got (%d, %v)", f.Offset(), err)
+	}
+	f.ReadByte()
+	if err := f.AlignRead(16); !errors.Is(err, io.ErrUnexpectedEOF) || f.Offset() != 8 {
+		t.Fatalf("Expected (8, io.ErrUnexpectedEOF); Got (%d, %v)", f.Offset(), err)
+	}
+	if err := f.AlignRead(0); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+	}
+}
+
+func TestFork(t *testing.T) {
+	f := NewFile([]byte("hello world"))
+	f.Seek(6, io.SeekStart)
+	g := f.Fork()
+	if exp, got := f.Offset(), g.Offset(); got != exp {
+		t.Fatalf("Expected offset %d; Got %d", exp, got)
+	}
+	if &f.Bytes()[0] != &g.Bytes()[0] {
+		t.Fatalf("Expected Fork to share the internal buffer")
+	}
+
+	g.WriteString("there")
+	if exp, got := "hello world", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if exp, got := "hello there", g.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	p := f.Bytes()
+	f.Reset().WriteString("bye")
+	if exp, got := "hello world", string(p); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	h := g.Fork()
+	h.Truncate(5)
+	g.PatchFunc(0, 1, func([]byte) []byte { return []byte("j") })
+	h.Seek(8, io.SeekStart)
+	if exp, got := "jello there", g.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if exp, got := "hello\x00\x00\x00", h.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	a := NewFile([]byte("hello world"))
+	b := a.Fork()
+	sec := a.Section(0, 5)
+	sec.WriteString("HELLO")
+	if exp, got := "HELLO", sec.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if exp, got := "hello world", b.StringRef(); got != exp {
+		t.Fatalf("Expected section write to be invisible to the Fork: %q; Got %q", exp, got)
+	}
+	if exp, got := "hello world", a.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
+
+func TestReadDir(t *testing.T) {
+	var f fs.File = &File{}
+	d, ok := f.(fs.ReadDirFile)
+	if !ok {
+		t.Fatalf("Expected File to implement fs.ReadDirFile")
+	}
+	if entries, err := d.ReadDir(-1); !errors.Is(err, fs.ErrInvalid) || entries != nil {
+		t.Fatalf("Expected (nil, fs.ErrInvalid); Got (%v, %v)", entries, err)
+	}
+}
+
+func TestPrintRepeat(t *testing.T) {
+	f := &File{}
+	f.PrintRepeat('─', 5).PrintRepeat('x', 0).PrintRepeat('=', 3)
+	if exp, got := strings.Repeat("─", 5)+"===", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	defer func() {
+		if v := recover(); v != "memio: PrintRepeat: negative count" {
+			t.Fatalf("Expected panic; Got %v", v)
+		}
+	}()
+	f.PrintRepeat('x', -1)
+}
+
+func TestLines(t *testing.T) {
+	f := NewFile([]byte("skip\na\r\n\nb"))
+	f.ReadLine()
+	lines, err := f.Lines()
+	if exp := []string{"a", "", "b"}; err != nil || !slices.Equal(lines, exp) {
+		t.Fatalf("Expected (%q, nil); Got (%q, %v)", exp, lines, err)
+	}
+	lines, err = f.Lines()
+	if err != nil || lines == nil || len(lines) != 0 {
+		t.Fatalf("Expected an empty slice; Got (%q, %v)", lines, err)
+	}
+}
+
+func TestBytesCopy(t *testing.T) {
+	f := NewFile([]byte("hello"))
+	p, q := f.Bytes(), f.BytesCopy()
+	f.WriteString("J")
+	if exp, got := "Jello", string(p); got != exp {
+		t.Fatalf("Expected Bytes to alias the internal buffer %q; Got %q", exp, got)
+	}
+	if exp, got := "hello", string(q); got != exp {
+		t.Fatalf("Expected BytesCopy to be unaffected %q; Got %q", exp, got)
+	}
+}
+
+func TestSeekWriteGap(t *testing.T) {
+	for _, stale := range []bool{false, true} {
+		f := &File{}
+		if stale {
+			// leave stale bytes in the spare capacity, which must not leak into the gap
+			f.WriteString("0123456789ab")
+			f.Reset()
+		}
+		f.WriteString("AB")
+		if _, err := f.Seek(8, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		f.WriteString("CD")
+		if exp, got := "AB\x00\x00\x00\x00\x00\x00CD", f.StringRef(); got != exp {
+			t.Fatalf("stale=%v: Expected %q; Got %q", stale, exp, got)
+		}
+		if exp, got := 10, f.Len(); got != exp {
+			t.Fatalf("stale=%v: Expected length %d; Got %d", stale, exp, got)
+		}
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	src := []byte("hello world")
+	f := NewReadOnly(src)
+	ops := map[string]func() error{
+		"Write":       func() error { _, err := f.Write([]byte("x")); return err },
+		"WriteString": func() error { _, err := f.WriteString("x"); return err },
+		"WriteByte":   func() error { return f.WriteByte('x') },
+		"WriteAt":     func() error { _, err := f.WriteAt([]byte("x"), 0); return err },
+		"ReadFrom":    func() error { _, err := f.ReadFrom(strings.NewReader("x")); return err },
+		"Seek":        func() error { _, err := f.Seek(100, io.SeekStart); return err },
+		"Section":     func() error { _, err := f.Section(0, 5).Write([]byte("x")); return err },
+	}
+	for name, op := range ops {
+		if err := op(); !errors.Is(err, fs.ErrPermission) {
+			t.Fatalf("%s: Expected fs.ErrPermission; Got %v", name, err)
+		}
+	}
+
+	if _, err := f.Seek(6, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := f.ReadN(5); err != nil || string(s) != "world" {
+		t.Fatalf("Expected (%q, nil); Got (%q, %v)", "world", s, err)
+	}
+	if exp, got := "hello world", string(src); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	for name, fn := range map[string]func(){
+		"WriteUint32":    func() { f.WriteUint32(binary.BigEndian, 1) },
+		"WriteVarString": func() { f.WriteVarString("x") },
+		"WriteBits":      func() { NewBitWriter(f).WriteBits(0xff, 8) },
+		"WriteU32":       func() { f.WithOrder(binary.BigEndian).WriteU32(1) },
+	} {
+		err := Try(fn)
+		me := (*MemioError)(nil)
+		if !errors.As(err, &me) || me.Op != "File.Expand" || !errors.Is(err, fs.ErrPermission) {
+			t.Fatalf("%s: Expected File.Expand error wrapping fs.ErrPermission; Got %v", name, err)
+		}
+	}
+	if exp, got := "hello world", string(src); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	f.SetReadOnly(false).Rewind().WriteString("J")
+	if exp, got := "Jello world", string(src); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
+
+func TestWriteOverwrite(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(f *File, s string)
+	}{
+		{"Write", func(f *File, s string) { f.Write([]byte(s)) }},
+		{"WriteString", func(f *File, s string) { f.WriteString(s) }},
+		{"PrintString", func(f *File, s string) { f.PrintString(s) }},
+	}
+	for _, c := range tests {
+		f := NewFile([]byte("0123456789"))
+		f.Seek(3, io.SeekStart)
+		c.write(f, "abc")
+		if exp, got := "012abc6789", f.StringRef(); got != exp {
+			t.Fatalf("%s: Expected %q; Got %q", c.name, exp, got)
+		}
+		if exp, got := int64(6), f.Offset(); got != exp {
+			t.Fatalf("%s: Expected offset %d; Got %d", c.name, exp, got)
+		}
+		c.write(f, "WXYZ+")
+		if exp, got := "012abcWXYZ+", f.StringRef(); got != exp {
+			t.Fatalf("%s: Expected %q; Got %q", c.name, exp, got)
+		}
+	}
+}
+
+func TestSyncFlush(t *testing.T) {
+	var f any = &File{}
+	if s, ok := f.(interface{ Sync() error }); !ok || s.Sync() != nil {
+		t.Fatalf("Expected Sync to be a no-op")
+	}
+	if s, ok := f.(interface{ Flush() error }); !ok || s.Flush() != nil {
+		t.Fatalf("Expected Flush to be a no-op")
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	f := &File{}
+	f.WriteString("header")
+	f.EnableChecksum(crc32.IEEETable)
+	f.WriteString("hello ")
+	f.WriteUint32(binary.BigEndian, 42)
+	f.ReadFrom(strings.NewReader(" world"))
+	f.WriteString("!")
+	exp := crc32.ChecksumIEEE(f.Bytes()[len("header"):])
+	if got := f.Checksum(); got != exp {
+		t.Fatalf("Expected %#x; Got %#x", exp, got)
+	}
+	f.WriteUint32(binary.BigEndian, exp)
+
+	f.ResetChecksum()
+	f.WriteString("more")
+	if exp, got := crc32.ChecksumIEEE([]byte("more")), f.Checksum(); got != exp {
+		t.Fatalf("Expected %#x; Got %#x", exp, got)
+	}
+	if exp, got := uint32(0), f.EnableChecksum(nil).Checksum(); got != exp {
+		t.Fatalf("Expected %#x; Got %#x", exp, got)
+	}
+
+	f = (&File{}).EnableChecksum(crc32.IEEETable)
+	f.WriteString("abc")
+	f.Seek(2, io.SeekStart)
+	f.Compact()
+	f.SetAppend(true).WriteString("d")
+	if exp, got := crc32.ChecksumIEEE([]byte("abcd")), f.Checksum(); got != exp {
+		t.Fatalf("Compact: Expected %#x; Got %#x", exp, got)
+	}
+
+	f = (&File{}).EnableChecksum(crc32.IEEETable)
+	f.WriteString("abc")
+	f.Rewind().DrainTo(io.Discard)
+	f.WriteString("de")
+	f.Truncate(1)
+	if exp, got := crc32.ChecksumIEEE([]byte("abcde")), f.Checksum(); got != exp {
+		t.Fatalf("DrainTo: Expected %#x; Got %#x", exp, got)
+	}
+
+	patchFirst := (&File{}).EnableChecksum(crc32.IEEETable)
+	mark := patchFirst.Reserve(4)
+	patchFirst.PatchUint32(mark, binary.BigEndian, 42)
+	patchFirst.WriteString("x")
+	patchLast := (&File{}).EnableChecksum(crc32.IEEETable)
+	mark = patchLast.Reserve(4)
+	patchLast.WriteString("x")
+	patchLast.PatchUint32(mark, binary.BigEndian, 42)
+	if !patchFirst.Equal(patchLast) {
+		t.Fatalf("Expected equal content; Got %q and %q", patchFirst.StringRef(), patchLast.StringRef())
+	}
+	if exp, got := crc32.ChecksumIEEE([]byte("\x00\x00\x00\x00x")), patchFirst.Checksum(); got != exp {
+		t.Fatalf("Patch first: Expected %#x; Got %#x", exp, got)
+	}
+	if exp, got := patchFirst.Checksum(), patchLast.Checksum(); got != exp {
+		t.Fatalf("Patch last: Expected %#x; Got %#x", exp, got)
+	}
+
+	f = (&File{}).EnableChecksum(crc32.IEEETable)
+	f.WriteAt([]byte("ab"), 0)
+	f.WriteAt([]byte("X"), 0)
+	if exp, got := crc32.ChecksumIEEE([]byte("ab")), f.Checksum(); got != exp {
+		t.Fatalf("WriteAt: Expected %#x; Got %#x", exp, got)
+	}
+}
+
+func TestIndexHelpers(t *testing.T) {
+	f := NewFile([]byte("a,b,c,d"))
+	f.ReadByte()
+	if exp, got := 0, f.IndexByte(','); got != exp {
+		t.Fatalf("Expected %d; Got %d", exp, got)
+	}
+	f.ReadByte()
+	i := f.IndexByte(',')
+	if exp := 1; i != exp {
+		t.Fatalf("Expected %d; Got %d", exp, i)
+	}
+	if exp, got := -1, f.IndexByte('x'); got != exp {
+		t.Fatalf("Expected %d; Got %d", exp, got)
+	}
+	if exp, got := 2, f.Count(','); got != exp {
+		t.Fatalf("Expected %d; Got %d", exp, got)
+	}
+	if !f.Contains([]byte("c,d")) || f.Contains([]byte("a,")) {
+		t.Fatalf("Expected Contains to only search the remaining bytes")
+	}
+	if exp, got := int64(2), f.Offset(); got != exp {
+		t.Fatalf("Expected offset %d; Got %d", exp, got)
+	}
+	f.Seek(int64(i), io.SeekCurrent)
+	if c, _ := f.ReadByte(); c != ',' {
+		t.Fatalf("Expected %q; Got %q", ',', c)
+	}
+}
+
+func TestCopyFrom(t *testing.T) {
+	src := strings.Repeat("0123456789", 100)
+	f := NewFile([]byte("head:"))
+	f.Seek(0, io.SeekEnd)
+	scratch := make([]byte, 7)
+	n, err := f.CopyFrom(strings.NewReader(src), scratch)
+	if err != nil || n != int64(len(src)) {
+		t.Fatalf("Expected (%d, nil); Got (%d, %v)", len(src), n, err)
+	}
+	if exp, got := "head:"+src, f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	f.Reset()
+	if n, err := f.CopyFrom(strings.NewReader(src), nil); err != nil || f.StringRef() != src {
+		t.Fatalf("Expected (%d, nil); Got (%d, %v)", len(src), n, err)
+	}
+	if _, err := f.CopyFrom(strings.NewReader(src), []byte{}); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+	}
+}
+
+func TestReservePatch(t *testing.T) {
+	f := &File{}
+	f.WriteString("hdr")
+	mark := f.Reserve(4)
+	f.WriteString("payload")
+	if err := f.PatchUint32(mark, binary.BigEndian, uint32(f.Len()-int(mark)-4)); err != nil {
+		t.Fatal(err)
+	}
+	if exp, got := "hdr\x00\x00\x00\x07payload", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if exp, got := int64(f.Len()), f.Offset(); got != exp {
+		t.Fatalf("Expected offset %d; Got %d", exp, got)
+	}
+	for _, mark := range []int64{-1, int64(f.Len() - 3)} {
+		if err := f.PatchUint32(mark, binary.BigEndian, 0); !errors.Is(err, fs.ErrInvalid) {
+			t.Fatalf("mark=%d: Expected fs.ErrInvalid; Got %v", mark, err)
+		}
+	}
+}
+
+func TestReaderCursor(t *testing.T) {
+	f := NewFile([]byte("hello world"))
+	f.Seek(3, io.SeekStart)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := f.Reader()
+			r.Seek(6, io.SeekStart)
+			p, err := io.ReadAll(r)
+			if err != nil || string(p) != "world" {
+				t.Errorf("Expected (%q, nil); Got (%q, %v)", "world", p, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if exp, got := int64(3), f.Offset(); got != exp {
+		t.Fatalf("Expected offset %d; Got %d", exp, got)
+	}
+	if _, ok := f.Reader().(io.Writer); ok {
+		t.Fatalf("Expected Reader not to implement io.Writer")
+	}
+	r := f.Reader()
+	if off, err := r.Seek(20, io.SeekStart); err != nil || off != 20 {
+		t.Fatalf("Expected (20, nil); Got (%d, %v)", off, err)
+	}
+	if n, err := r.Read(make([]byte, 1)); n != 0 || err != io.EOF {
+		t.Fatalf("Expected (0, io.EOF); Got (%d, %v)", n, err)
+	}
+	if exp, got := "hello world", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
+
+func TestStringEmpty(t *testing.T) {
+	for name, f := range map[string]*File{
+		"zero":     {},
+		"empty":    NewFile([]byte{}),
+		"reset":    NewFile([]byte("hello")).Reset(),
+		"truncate": NewFile(make([]byte, 0, 8)).Truncate(0),
+	} {
+		if got := f.String(); got != "" {
+			t.Fatalf("%s: Expected String() == %q; Got %q", name, "", got)
+		}
+		if got := f.StringRef(); got != "" {
+			t.Fatalf("%s: Expected StringRef() == %q; Got %q", name, "", got)
+		}
+	}
+}
+
+func TestEnsureLen(t *testing.T) {
+	f := NewFile([]byte("ab"))
+	f.ReadByte()
+	f.EnsureLen(5).EnsureLen(3)
+	if exp, got := "ab\x00\x00\x00", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if exp, got := int64(1), f.Offset(); got != exp {
+		t.Fatalf("Expected offset %d; Got %d", exp, got)
+	}
+	if !f.IsHole(3) || f.IsHole(1) {
+		t.Fatalf("Expected the added bytes to be a hole")
+	}
+}
+
+func TestModeModTime(t *testing.T) {
+	f := &File{}
+	if exp, got := fs.FileMode(0o644), f.Mode(); got != exp || !got.IsRegular() {
+		t.Fatalf("Expected %v; Got %v", exp, got)
+	}
+	if !f.ModTime().IsZero() {
+		t.Fatalf("Expected zero time; Got %v", f.ModTime())
+	}
+
+	now := time.Now()
+	f.SetMode(0o600).SetModTime(now)
+	fi, _ := f.Stat()
+	if exp, got := fs.FileMode(0o600), fi.Mode(); got != exp {
+		t.Fatalf("Expected %v; Got %v", exp, got)
+	}
+	if !fi.ModTime().Equal(now) {
+		t.Fatalf("Expected %v; Got %v", now, fi.ModTime())
+	}
+	if f.SetMode(0).Mode() != 0 {
+		t.Fatalf("Expected an explicit zero mode to be kept; Got %v", f.Mode())
+	}
+}
+
+func TestPrintLine(t *testing.T) {
+	f := &File{}
+	f.PrintLine("a", "b", "c").PrintLine().PrintLinef("%d-%s", 1, "x").PrintLinef("done")
+	if exp, got := "abc\n\n1-x\ndone\n", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
+
+func TestUnreadN(t *testing.T) {
+	f := NewFile([]byte("foo+=bar"))
+	tok, _ := f.ReadN(5)
+	if exp, got := "foo+=", string(tok); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if err := f.UnreadN(2); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := f.ReadString('='); s != "+" {
+		t.Fatalf("Expected %q; Got %q", "+", s)
+	}
+	if exp, got := int64(5), f.BytesRead(); got != exp {
+		t.Fatalf("Expected %d bytes read; Got %d", exp, got)
+	}
+	for _, n := range []int{-1, 6} {
+		if err := f.UnreadN(n); !errors.Is(err, fs.ErrInvalid) {
+			t.Fatalf("n=%d: Expected fs.ErrInvalid; Got %v", n, err)
+		}
+	}
+	if exp, got := int64(5), f.Offset(); got != exp {
+		t.Fatalf("Expected offset %d; Got %d", exp, got)
+	}
+}
+
+func TestAppendFile(t *testing.T) {
+	head, body := NewFile([]byte("head:")), NewFile([]byte("body"))
+	body.ReadByte()
+	f := &File{}
+	f.AppendFile(head).AppendFile(body).AppendFile(f)
+	if exp, got := "head:bodyhead:body", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if exp, got := int64(1), body.Offset(); got != exp {
+		t.Fatalf("Expected offset %d; Got %d", exp, got)
+	}
+
+	if n, err := f.WriteFileAt(body, 20); err != nil || n != 4 {
+		t.Fatalf("Expected (4, nil); Got (%d, %v)", n, err)
+	}
+	if exp, got := "head:bodyhead:body\x00\x00body", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if _, err := f.WriteFileAt(body, -1); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+	}
+}
+
+func TestWriteFuncN(t *testing.T) {
+	ts := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	f := NewFile([]byte("at: XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX"))
+	f.Seek(4, io.SeekStart)
+	f.WriteFuncN(64, func(dst []byte) int {
+		return len(ts.AppendFormat(dst, time.RFC3339))
+	})
+	if exp, got := "at: 2024-05-06T07:08:09ZXXXXXXXXXXXXXXXXXX", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if exp, got := int64(24), f.Offset(); got != exp {
+		t.Fatalf("Expected offset %d; Got %d", exp, got)
+	}
+
+	f.Seek(0, io.SeekEnd)
+	f.WriteFuncN(8, func(dst []byte) int { return len(append(dst, "!"...)) })
+	if exp, got := "at: 2024-05-06T07:08:09ZXXXXXXXXXXXXXXXXXX!", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if exp, got := int64(f.Len()), f.Offset(); got != exp {
+		t.Fatalf("Expected offset %d; Got %d", exp, got)
+	}
+
+	f = &File{}
+	f.Seek(10, io.SeekStart)
+	f.Seek(0, io.SeekStart)
+	f.WriteFuncN(8, func(dst []byte) int { return len(append(dst, "ab"...)) })
+	if !f.IsHole(5) || f.IsHole(1) || f.DataLen() != 2 || f.Len() != 10 {
+		t.Fatalf("Expected only [0:2] to be written; Got IsHole(5)=%v, IsHole(1)=%v, DataLen()=%d, Len()=%d", f.IsHole(5), f.IsHole(1), f.DataLen(), f.Len())
+	}
+	f.Seek(0, io.SeekEnd)
+	f.WriteFuncN(8, func(dst []byte) int { return len(append(dst, "c"...)) })
+	if exp, got := "ab\x00\x00\x00\x00\x00\x00\x00\x00c", f.StringRef(); got != exp || f.DataLen() != 11 || !f.IsHole(9) {
+		t.Fatalf("Expected %q, DataLen()=11, IsHole(9); Got %q, %d, %v", exp, got, f.DataLen(), f.IsHole(9))
+	}
+
+	defer func() {
+		if v := recover(); v != "memio: WriteFuncN: invalid count" {
+			t.Fatalf("Expected panic; Got %v", v)
+		}
+	}()
+	f.WriteFuncN(1, func(dst []byte) int { return 2 })
+}
+
+func TestReadFromDataEOF(t *testing.T) {
+	src := strings.Repeat("0123456789", 500)
+	// DataErrReader returns the final bytes together with io.EOF, and HalfReader forces partial reads
+	r := iotest.DataErrReader(iotest.HalfReader(strings.NewReader(src)))
+	f := NewFile([]byte("head:XXXX"))
+	f.Seek(5, io.SeekStart)
+	n, err := f.ReadFrom(r)
+	if err != nil || n != int64(len(src)) {
+		t.Fatalf("Expected (%d, nil); Got (%d, %v)", len(src), n, err)
+	}
+	if exp, got := "head:"+src, f.StringRef(); got != exp {
+		t.Fatalf("Expected %d bytes; Got %d", len(exp), len(got))
+	}
+	if exp, got := int64(f.Len()), f.Offset(); got != exp {
+		t.Fatalf("Expected offset %d; Got %d", exp, got)
+	}
+}
+
+func TestMaxSize(t *testing.T) {
+	f := (&File{}).SetMaxSize(8)
+	n, err := f.WriteString("hello world")
+	if n != 8 || !errors.Is(err, ErrMaxSizeExceeded) {
+		t.Fatalf("Expected (8, ErrMaxSizeExceeded); Got (%d, %v)", n, err)
+	}
+	if exp, got := "hello wo", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if err := f.WriteByte('!'); !errors.Is(err, ErrMaxSizeExceeded) {
+		t.Fatalf("Expected ErrMaxSizeExceeded; Got %v", err)
+	}
+	if _, err := f.Seek(9, io.SeekStart); !errors.Is(err, ErrMaxSizeExceeded) {
+		t.Fatalf("Expected ErrMaxSizeExceeded; Got %v", err)
+	}
+
+	// overwriting within the limit is fine
+	f.Rewind()
+	if _, err := f.Write([]byte("HELLO")); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := f.WriteAt([]byte("xyz"), 6); n != 2 || !errors.Is(err, ErrMaxSizeExceeded) {
+		t.Fatalf("Expected (2, ErrMaxSizeExceeded); Got (%d, %v)", n, err)
+	}
+	if exp, got := "HELLO xy", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	f = (&File{}).SetMaxSize(1 << 12)
+	src := strings.Repeat("x", 1<<13)
+	if n, err := f.ReadFrom(strings.NewReader(src)); n != 1<<12 || !errors.Is(err, ErrMaxSizeExceeded) {
+		t.Fatalf("Expected (%d, ErrMaxSizeExceeded); Got (%d, %v)", 1<<12, n, err)
+	}
+	if f.Cap() > 1<<12 {
+		t.Fatalf("Expected capacity <= %d; Got %d", 1<<12, f.Cap())
+	}
+	f.Reset()
+	if n, err := f.ReadFrom(struct{ io.Reader }{strings.NewReader(src[:1<<12])}); n != 1<<12 || err != nil {
+		t.Fatalf("Expected (%d, nil); Got (%d, %v)", 1<<12, n, err)
+	}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrMaxSizeExceeded) {
+			t.Fatalf("Expected panic with ErrMaxSizeExceeded; Got %v", err)
+		}
+	}()
+	f.WriteUint32(binary.BigEndian, 1)
+}
+
+func TestTake(t *testing.T) {
+	f := &File{}
+	f.WriteString("hello")
+	p := f.Bytes()
+	s := f.TakeString()
+	if s != "hello" || unsafe.StringData(s) != &p[0] {
+		t.Fatalf("Expected %q without copying; Got %q", "hello", s)
+	}
+	if f.Len() != 0 || f.Offset() != 0 || f.Cap() != 0 {
+		t.Fatalf("Expected an empty File; Got length %d, offset %d, capacity %d", f.Len(), f.Offset(), f.Cap())
+	}
+	f.WriteString("HELLO")
+	if s != "hello" {
+		t.Fatalf("Expected %q; Got %q", "hello", s)
+	}
+
+	p = f.TakeBytes()
+	if string(p) != "HELLO" || f.Len() != 0 || f.Cap() != 0 {
+		t.Fatalf("Expected (%q, empty File); Got (%q, %q)", "HELLO", p, f.StringRef())
+	}
+
+	f.WriteString("fork")
+	g := f.Fork()
+	p = f.TakeBytes()
+	p[0] = 'F'
+	if exp, got := "fork", g.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	p = NewFile(nil).ResetString("constant").TakeBytes()
+	p[0] = 'C'
+	if exp, got := "Constant", string(p); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	src := []byte("read-only")
+	s = NewReadOnly(src).TakeString()
+	src[0] = 'R'
+	if exp := "read-only"; s != exp {
+		t.Fatalf("Expected %q; Got %q", exp, s)
+	}
+}
+
+func TestWriteRangeTo(t *testing.T) {
+	f := NewFile([]byte("header|body"))
+	f.Seek(2, io.SeekStart)
+	w := &chunkWriter{n: 2}
+	n, err := f.WriteRangeTo(w, 7, 11)
+	if err != nil || n != 4 || w.buf.String() != "body" {
+		t.Fatalf("Expected (4, nil, %q); Got (%d, %v, %q)", "body", n, err, w.buf.String())
+	}
+	if exp, got := int64(2), f.Offset(); got != exp {
+		t.Fatalf("Expected offset %d; Got %d", exp, got)
+	}
+	for _, r := range [][2]int64{{-1, 2}, {5, 4}, {0, 12}} {
+		if _, err := f.WriteRangeTo(w, r[0], r[1]); !errors.Is(err, fs.ErrInvalid) {
+			t.Fatalf("%v: Expected fs.ErrInvalid; Got %v", r, err)
+		}
+	}
+}
+
+func TestReadEmpty(t *testing.T) {
+	f := NewFile([]byte("x"))
+	f.Seek(0, io.SeekEnd)
+	if n, err := f.Read(nil); n != 0 || err != nil {
+		t.Fatalf("Expected (0, nil); Got (%d, %v)", n, err)
+	}
+	if n, err := f.Read(make([]byte, 1)); n != 0 || err != io.EOF {
+		t.Fatalf("Expected (0, io.EOF); Got (%d, %v)", n, err)
+	}
+}
+
+func TestSplitN(t *testing.T) {
+	tests := []struct {
+		n   int
+		exp []string
+	}{
+		{0, []string{"a", "b", "", "c"}},
+		{-1, []string{"a", "b", "", "c"}},
+		{1, []string{"a:b::c"}},
+		{2, []string{"a", "b::c"}},
+		{10, []string{"a", "b", "", "c"}},
+	}
+	for _, c := range tests {
+		f := NewFile([]byte("key=a:b::c"))
+		f.ReadString('=')
+		var got []string
+		for _, p := range f.SplitN(':', c.n) {
+			got = append(got, string(p))
+		}
+		if !slices.Equal(got, c.exp) {
+			t.Fatalf("n=%d: Expected %q; Got %q", c.n, c.exp, got)
+		}
+		if f.RemainingLen() != 0 {
+			t.Fatalf("n=%d: Expected no bytes remaining; Got %d", c.n, f.RemainingLen())
+		}
+		if f.SplitN(':', c.n) != nil {
+			t.Fatalf("n=%d: Expected nil at the end", c.n)
+		}
+	}
+}
+
+func TestWriteOffset(t *testing.T) {
+	f := NewFile(nil)
+	offs := []int64{}
+	for _, s := range []string{"a", "bc", "def"} {
+		off, err := f.WriteOffset([]byte(s))
+		if err != nil {
+			t.Fatalf("WriteOffset(%q): %s", s, err)
+		}
+		offs = append(offs, off)
+	}
+	if exp := []int64{0, 1, 3}; !slices.Equal(offs, exp) {
+		t.Fatalf("Expected %v; Got %v", exp, offs)
+	}
+
+	f.Seek(0, io.SeekStart)
+	f.SetAppend(true)
+	if got := f.At(); got != 6 {
+		t.Fatalf("Expected At() 6 in append mode; Got %d", got)
+	}
+	if off, _ := f.WriteOffset([]byte("g")); off != 6 {
+		t.Fatalf("Expected offset 6 in append mode; Got %d", off)
+	}
+	if got := f.String(); got != "abcdefg" {
+		t.Fatalf("Expected %q; Got %q", "abcdefg", got)
+	}
+}
+
+func TestPrintUnwritable(t *testing.T) {
+	printAll := func(f *File) {
+		f.PrintString("s").PrintRune('r').PrintLine("line").PrintLinef("%d", 1).
+			PrintRepeat('-', 3).PrintInt(-1).PrintUint(1).PrintFloat(1.5, 'g', -1).
+			PrintHex([]byte{1}).PrintBase64([]byte{1}, base64.StdEncoding)
+	}
+	for name, f := range map[string]*File{
+		"read-only": NewReadOnly([]byte("hello")),
+		"max size":  NewFile([]byte("hello")).SetMaxSize(5).SetAppend(true),
+		"closed": func() *File {
+			f := NewFile([]byte("hello")).SetStrictClose(true)
+			f.Close()
+			return f
+		}(),
+	} {
+		printAll(f)
+		if exp, got := "hello", f.StringRef(); got != exp {
+			t.Fatalf("%s: Expected %q; Got %q", name, exp, got)
+		}
+	}
+
+	f := NewFile(nil).SetMaxSize(6)
+	f.PrintString("abc").PrintLinef("%s", "def").PrintLine("de")
+	if exp, got := "abcde\n", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
 }
@@ -0,0 +1,203 @@
+package memio
+
+import (
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	_ fs.ReadDirFS   = (*FS)(nil)
+	_ fs.StatFS      = (*FS)(nil)
+	_ fs.ReadDirFile = (*fsDir)(nil)
+	_ fs.FileInfo    = (*fsFile)(nil)
+)
+
+// FS implements an in-memory fs.FS backed by named Files
+//
+// Directories are implied by the names of the files added to it.
+// It's safe to call Open, ReadDir and Stat concurrently, but not concurrently with Add.
+type FS struct {
+	files map[string]*File
+}
+
+// Add adds f to the filesystem as name
+//
+// name should be a valid path as defined by fs.ValidPath, otherwise it cannot be opened.
+// f is not copied, so subsequent changes to f are visible to files opened later.
+func (m *FS) Add(name string, f *File) {
+	if m.files == nil {
+		m.files = map[string]*File{}
+	}
+	m.files[name] = f
+}
+
+// Open implements fs.FS
+//
+// Each call returns a fresh read-only cursor over the named file's bytes,
+// so concurrent opens of the same file don't share a position, and writes through it can't modify the file.
+// Its Stat reports the mode and modification time of the named file, like FS.Stat.
+func (m *FS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if f, ok := m.files[name]; ok {
+		c := NewReadOnly(f.buf)
+		c.mode, c.hasMode, c.modTime = f.mode, f.hasMode, f.modTime
+		return &fsFile{File: c, name: path.Base(name)}, nil
+	}
+	entries, ok := m.readDir(name)
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &fsDir{name: path.Base(name), entries: entries}, nil
+}
+
+// ReadDir implements fs.ReadDirFS
+func (m *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	if _, ok := m.files[name]; ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	entries, ok := m.readDir(name)
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	return entries, nil
+}
+
+// Stat implements fs.StatFS
+func (m *FS) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+	if f, ok := m.files[name]; ok {
+		return &fsFile{File: f, name: path.Base(name)}, nil
+	}
+	if _, ok := m.readDir(name); !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return &fsDir{name: path.Base(name)}, nil
+}
+
+// readDir returns the sorted entries of directory dir, and whether the directory exists
+func (m *FS) readDir(dir string) ([]fs.DirEntry, bool) {
+	prefix := ""
+	if dir != "." {
+		prefix = dir + "/"
+	}
+	entries := []fs.DirEntry{}
+	seen := map[string]bool{}
+	for name, f := range m.files {
+		rel, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			continue
+		}
+		if i := strings.IndexByte(rel, '/'); i >= 0 {
+			sub := rel[:i]
+			if !seen[sub] {
+				seen[sub] = true
+				entries = append(entries, fs.FileInfoToDirEntry(&fsDir{name: sub}))
+			}
+			continue
+		}
+		entries = append(entries, fs.FileInfoToDirEntry(&fsFile{File: f, name: rel}))
+	}
+	if len(entries) == 0 && dir != "." {
+		return nil, false
+	}
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return entries, true
+}
+
+// fsFile is a named File returned by FS
+type fsFile struct {
+	*File
+	name string
+}
+
+// Name implements the fs.FileInfo.Name interface
+func (f *fsFile) Name() string {
+	return f.name
+}
+
+// Stat implements the fs.File.Stat interface
+func (f *fsFile) Stat() (fs.FileInfo, error) {
+	return f, nil
+}
+
+// fsDir is a directory implied by the names of the files in an FS
+type fsDir struct {
+	name    string
+	entries []fs.DirEntry
+}
+
+// Read implements the fs.File.Read interface
+//
+// It always returns an error because directories cannot be read
+func (d *fsDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: fs.ErrInvalid}
+}
+
+// ReadDir implements the fs.ReadDirFile interface
+func (d *fsDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if n <= 0 {
+		s := d.entries
+		d.entries = nil
+		return s, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(d.entries))
+	s := d.entries[:n:n]
+	d.entries = d.entries[n:]
+	return s, nil
+}
+
+// Stat implements the fs.File.Stat interface
+func (d *fsDir) Stat() (fs.FileInfo, error) {
+	return d, nil
+}
+
+// Close implements the fs.File.Close interface
+func (d *fsDir) Close() error {
+	return nil
+}
+
+// Name implements the fs.FileInfo.Name interface
+func (d *fsDir) Name() string {
+	return d.name
+}
+
+// Size implements the fs.FileInfo.Size interface
+func (d *fsDir) Size() int64 {
+	return 0
+}
+
+// Mode implements the fs.FileInfo.Mode interface
+func (d *fsDir) Mode() fs.FileMode {
+	return fs.ModeDir | 0o555
+}
+
+// ModTime implements the fs.FileInfo.ModTime interface
+func (d *fsDir) ModTime() time.Time {
+	return time.Time{}
+}
+
+// IsDir implements the fs.FileInfo.IsDir interface
+func (d *fsDir) IsDir() bool {
+	return true
+}
+
+// Sys implements the fs.FileInfo.Sys interface
+func (d *fsDir) Sys() any {
+	return nil
+}
@@ -0,0 +1,61 @@
+package memio
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestFS(t *testing.T) {
+	m := &FS{}
+	m.Add("hello.txt", NewFile([]byte("hello")))
+	m.Add("a/b/world.txt", NewFile([]byte("world")))
+	m.Add("a/c.txt", NewFile([]byte("c")))
+
+	if err := fstest.TestFS(m, "hello.txt", "a/b/world.txt", "a/c.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	f1, err := m.Open("hello.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f2, err := m.Open("hello.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := io.ReadAll(f1)
+	if exp, got := "hello", string(s); got != exp {
+		t.Fatalf("Expected `%s`; Got `%s`", exp, got)
+	}
+	s, _ = io.ReadAll(f2)
+	if exp, got := "hello", string(s); got != exp {
+		t.Fatalf("Expected `%s`; Got `%s`", exp, got)
+	}
+
+	if _, err := f1.(io.Writer).Write([]byte("HE")); !errors.Is(err, fs.ErrPermission) {
+		t.Fatalf("Expected error wrapping fs.ErrPermission; Got %v", err)
+	}
+	if exp, got := "hello", m.files["hello.txt"].String(); got != exp {
+		t.Fatalf("Expected `%s`; Got `%s`", exp, got)
+	}
+
+	m.Add("dated.txt", NewFile([]byte("x")).SetMode(0o600).SetModTime(time.Unix(1000, 0)))
+	df, err := m.Open("dated.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fi, _ := df.Stat()
+	if fi.Mode() != 0o600 || !fi.ModTime().Equal(time.Unix(1000, 0)) || fi.Name() != "dated.txt" {
+		t.Fatalf("Expected (dated.txt, %v, %v); Got (%s, %v, %v)", fs.FileMode(0o600), time.Unix(1000, 0), fi.Name(), fi.Mode(), fi.ModTime())
+	}
+
+	_, err = m.Open("missing.txt")
+	pe := (*fs.PathError)(nil)
+	if !errors.As(err, &pe) || !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Expected *fs.PathError wrapping fs.ErrNotExist; Got %#v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package memio
+
+import (
+	"compress/gzip"
+	"io"
+)
+
+// GzipTo writes the gzip-compressed content of the entire internal buffer to w, without changing the current position
+func (f *File) GzipTo(w io.Writer) error {
+	zw := gzip.NewWriter(w)
+	if _, err := f.CopyAllTo(zw); err != nil {
+		return wrapErr("File.GzipTo", err)
+	}
+	if err := zw.Close(); err != nil {
+		return &MemioError{Op: "File.GzipTo", Err: err}
+	}
+	return nil
+}
+
+// InflateFrom reads gzip-compressed data from r and writes the decompressed data at the current position, like ReadFrom
+func (f *File) InflateFrom(r io.Reader) error {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return &MemioError{Op: "File.InflateFrom", Err: err}
+	}
+	if _, err := f.ReadFrom(zr); err != nil {
+		return wrapErr("File.InflateFrom", err)
+	}
+	if err := zr.Close(); err != nil {
+		return &MemioError{Op: "File.InflateFrom", Err: err}
+	}
+	return nil
+}
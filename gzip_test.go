@@ -0,0 +1,52 @@
+package memio
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+)
+
+func TestGzip(t *testing.T) {
+	src := NewFile(bytes.Repeat([]byte("hello world "), 100))
+	z := &File{}
+	if err := src.GzipTo(z); err != nil {
+		t.Fatal(err)
+	}
+	if z.Len() >= src.Len() {
+		t.Fatalf("Expected compressed length < %d; Got %d", src.Len(), z.Len())
+	}
+
+	dst := &File{}
+	if err := dst.InflateFrom(z.Rewind()); err != nil {
+		t.Fatal(err)
+	}
+	if !dst.Equal(src) {
+		t.Fatalf("Expected %q; Got %q", src.StringRef(), dst.StringRef())
+	}
+
+	if err := dst.InflateFrom(NewFile([]byte("definitely not gzip"))); !errors.Is(err, gzip.ErrHeader) {
+		t.Fatalf("Expected gzip.ErrHeader; Got %v", err)
+	}
+}
+
+func TestGzipErrors(t *testing.T) {
+	f := NewFile([]byte("hello")).SetStrictClose(true)
+	f.Close()
+	err := f.GzipTo(&File{})
+	if exp, got := "File.GzipTo: file already closed", fmt.Sprint(err); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+	if !errors.Is(err, fs.ErrClosed) {
+		t.Fatalf("Expected error wrapping fs.ErrClosed; Got %v", err)
+	}
+
+	z := &File{}
+	NewFile([]byte("hello")).GzipTo(z)
+	err = NewReadOnly(nil).InflateFrom(z.Rewind())
+	if exp, got := "File.InflateFrom: permission denied", fmt.Sprint(err); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
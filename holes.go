@@ -0,0 +1,95 @@
+package memio
+
+import (
+	"sort"
+)
+
+// span is a range [start:end] of the internal buffer
+type span struct {
+	start int
+	end   int
+}
+
+// DataLen returns the offset after the last written byte
+//
+// Unlike Len, it excludes the trailing hole created by seeking or truncating past the end.
+func (f *File) DataLen() int {
+	if n := len(f.holes); n > 0 && f.holes[n-1].end == len(f.buf) {
+		return f.holes[n-1].start
+	}
+	return len(f.buf)
+}
+
+// IsHole reports whether the byte at offset off is part of a hole
+//
+// Holes are zero-filled ranges created by seeking or truncating past the end, that haven't been written to since.
+func (f *File) IsHole(off int64) bool {
+	i := sort.Search(len(f.holes), func(i int) bool {
+		return int64(f.holes[i].end) > off
+	})
+	return i < len(f.holes) && int64(f.holes[i].start) <= off
+}
+
+// addHole records range [start:end] at the end of the internal buffer as a hole
+func (f *File) addHole(start, end int) {
+	if start >= end {
+		return
+	}
+	if n := len(f.holes); n > 0 && f.holes[n-1].end == start {
+		f.holes[n-1].end = end
+		return
+	}
+	f.holes = append(f.holes, span{start: start, end: end})
+}
+
+// fillHoles records range [start:end] as written
+func (f *File) fillHoles(start, end int) {
+	if len(f.holes) == 0 || start >= end {
+		return
+	}
+	i := sort.Search(len(f.holes), func(i int) bool {
+		return f.holes[i].end > start
+	})
+	if i == len(f.holes) || f.holes[i].start >= end {
+		return
+	}
+	holes := append([]span(nil), f.holes[:i]...)
+	for _, h := range f.holes[i:] {
+		if h.start >= end {
+			holes = append(holes, h)
+			continue
+		}
+		if h.start < start {
+			holes = append(holes, span{start: h.start, end: start})
+		}
+		if h.end > end {
+			holes = append(holes, span{start: end, end: h.end})
+		}
+	}
+	f.holes = holes
+}
+
+// clipHoles discards the holes, or parts of holes, after offset n
+func (f *File) clipHoles(n int) {
+	for i := len(f.holes) - 1; i >= 0; i-- {
+		h := &f.holes[i]
+		if h.start >= n {
+			f.holes = f.holes[:i]
+			continue
+		}
+		h.end = min(h.end, n)
+		break
+	}
+}
+
+// shiftHoles moves the holes back by n bytes, discarding holes, or parts of holes, before offset 0
+func (f *File) shiftHoles(n int) {
+	holes := f.holes[:0]
+	for _, h := range f.holes {
+		if h.end <= n {
+			continue
+		}
+		holes = append(holes, span{start: max(h.start-n, 0), end: h.end - n})
+	}
+	f.holes = holes
+}
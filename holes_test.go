@@ -0,0 +1,44 @@
+package memio
+
+import (
+	"io"
+	"testing"
+)
+
+func TestHoles(t *testing.T) {
+	f := &File{}
+	f.WriteString("abc")
+	f.Seek(10, io.SeekStart)
+	f.WriteString("x")
+	f.Truncate(15)
+	f.WriteAt([]byte("y"), 5)
+
+	holes := ""
+	for i := int64(0); i < int64(f.Len()); i++ {
+		if f.IsHole(i) {
+			holes += "_"
+		} else {
+			holes += "D"
+		}
+	}
+	if exp, got := "DDD__D____D____", holes; got != exp {
+		t.Fatalf("Expected holes %q; Got %q", exp, got)
+	}
+	if exp, got := 11, f.DataLen(); got != exp {
+		t.Fatalf("Expected DataLen() %d; Got %d", exp, got)
+	}
+
+	f.Seek(4, io.SeekStart)
+	f.Compact()
+	if exp, got := 7, f.DataLen(); got != exp {
+		t.Fatalf("Expected DataLen() %d; Got %d", exp, got)
+	}
+	if !f.IsHole(0) || f.IsHole(1) || !f.IsHole(2) || f.IsHole(6) {
+		t.Fatalf("Expected holes to move with Compact")
+	}
+
+	f.Truncate(3)
+	if exp, got := 2, f.DataLen(); got != exp || !f.IsHole(2) || f.IsHole(3) {
+		t.Fatalf("Expected DataLen() %d; Got %d", exp, got)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !race
+
+package memio
+
+// raceEnabled is true if the race detector is enabled, which changes allocation counts
+const raceEnabled = false
@@ -0,0 +1,106 @@
+package memio
+
+import (
+	"encoding/binary"
+)
+
+// OrderedFile binds a byte order to a File, so it doesn't need to be passed to every call
+type OrderedFile struct {
+	*File
+	Order binary.ByteOrder
+}
+
+// WithOrder returns an OrderedFile wrapping f with byte order o
+func (f *File) WithOrder(o binary.ByteOrder) OrderedFile {
+	return OrderedFile{File: f, Order: o}
+}
+
+// ReadU16 is a wrapper around ReadUint16(Order)
+func (f OrderedFile) ReadU16() (uint16, error) {
+	return f.ReadUint16(f.Order)
+}
+
+// ReadU24 is a wrapper around ReadUint24(Order)
+func (f OrderedFile) ReadU24() (uint32, error) {
+	return f.ReadUint24(f.Order)
+}
+
+// ReadU32 is a wrapper around ReadUint32(Order)
+func (f OrderedFile) ReadU32() (uint32, error) {
+	return f.ReadUint32(f.Order)
+}
+
+// ReadU64 is a wrapper around ReadUint64(Order)
+func (f OrderedFile) ReadU64() (uint64, error) {
+	return f.ReadUint64(f.Order)
+}
+
+// ReadI16 is a wrapper around ReadInt16(Order)
+func (f OrderedFile) ReadI16() (int16, error) {
+	return f.ReadInt16(f.Order)
+}
+
+// ReadI32 is a wrapper around ReadInt32(Order)
+func (f OrderedFile) ReadI32() (int32, error) {
+	return f.ReadInt32(f.Order)
+}
+
+// ReadI64 is a wrapper around ReadInt64(Order)
+func (f OrderedFile) ReadI64() (int64, error) {
+	return f.ReadInt64(f.Order)
+}
+
+// ReadF32 is a wrapper around ReadFloat32(Order)
+func (f OrderedFile) ReadF32() (float32, error) {
+	return f.ReadFloat32(f.Order)
+}
+
+// ReadF64 is a wrapper around ReadFloat64(Order)
+func (f OrderedFile) ReadF64() (float64, error) {
+	return f.ReadFloat64(f.Order)
+}
+
+// WriteU16 is a wrapper around WriteUint16(Order, n)
+func (f OrderedFile) WriteU16(n uint16) {
+	f.WriteUint16(f.Order, n)
+}
+
+// WriteU24 is a wrapper around WriteUint24(Order, n)
+func (f OrderedFile) WriteU24(n uint32) {
+	f.WriteUint24(f.Order, n)
+}
+
+// WriteU32 is a wrapper around WriteUint32(Order, n)
+func (f OrderedFile) WriteU32(n uint32) {
+	f.WriteUint32(f.Order, n)
+}
+
+// WriteU64 is a wrapper around WriteUint64(Order, n)
+func (f OrderedFile) WriteU64(n uint64) {
+	f.WriteUint64(f.Order, n)
+}
+
+// WriteI16 is a wrapper around WriteInt16(Order, n)
+func (f OrderedFile) WriteI16(n int16) {
+	f.WriteInt16(f.Order, n)
+}
+
+// WriteI32 is a wrapper around WriteInt32(Order, n)
+func (f OrderedFile) WriteI32(n int32) {
+	f.WriteInt32(f.Order, n)
+}
+
+// WriteI64 is a wrapper around WriteInt64(Order, n)
+func (f OrderedFile) WriteI64(n int64) {
+	f.WriteInt64(f.Order, n)
+}
+
+// WriteF32 is a wrapper around WriteFloat32(Order, n)
+func (f OrderedFile) WriteF32(n float32) {
+	f.WriteFloat32(f.Order, n)
+}
+
+// WriteF64 is a wrapper around WriteFloat64(Order, n)
+func (f OrderedFile) WriteF64(n float64) {
+	f.WriteFloat64(f.Order, n)
+}
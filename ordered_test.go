@@ -0,0 +1,31 @@
+package memio
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestOrderedFile(t *testing.T) {
+	f := (&File{}).WithOrder(binary.BigEndian)
+	f.WriteU16(0x0102)
+	f.WriteU32(0x03040506)
+	f.WriteI64(-1)
+	f.WriteF64(1.5)
+	if exp, got := "\x01\x02\x03\x04\x05\x06", f.StringRef()[:6]; got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	f.Rewind()
+	if n, err := f.ReadU16(); err != nil || n != 0x0102 {
+		t.Fatalf("Expected (0x0102, nil); Got (%#x, %v)", n, err)
+	}
+	if n, err := f.ReadU32(); err != nil || n != 0x03040506 {
+		t.Fatalf("Expected (0x03040506, nil); Got (%#x, %v)", n, err)
+	}
+	if n, err := f.ReadI64(); err != nil || n != -1 {
+		t.Fatalf("Expected (-1, nil); Got (%d, %v)", n, err)
+	}
+	if n, err := f.ReadF64(); err != nil || n != 1.5 {
+		t.Fatalf("Expected (1.5, nil); Got (%v, %v)", n, err)
+	}
+}
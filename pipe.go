@@ -0,0 +1,107 @@
+package memio
+
+import (
+	"io"
+	"sync"
+)
+
+var (
+	_ io.Reader       = (*PipeFile)(nil)
+	_ io.Writer       = (*PipeFile)(nil)
+	_ io.StringWriter = (*PipeFile)(nil)
+)
+
+// PipeFile is an in-memory pipe for a single producer and a single consumer
+//
+// Unlike io.Pipe, writes never block: they're buffered in a File until they're read.
+// Read blocks until data is available or CloseWrite is called.
+// It must be created with NewPipeFile.
+type PipeFile struct {
+	mu     sync.Mutex
+	cond   sync.Cond
+	f      File
+	rpos   int64
+	closed bool
+}
+
+// Read implements io.Reader
+//
+// It blocks until data is available, or returns io.EOF after CloseWrite is called and all data has been read.
+func (p *PipeFile) Read(s []byte) (int, error) {
+	if len(s) == 0 {
+		return 0, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for p.rpos == int64(p.f.Len()) && !p.closed {
+		p.cond.Wait()
+	}
+	if p.rpos == int64(p.f.Len()) {
+		return 0, io.EOF
+	}
+	n, _ := p.f.Restore(p.rpos).Read(s)
+	p.rpos = p.f.Mark()
+	if p.rpos == int64(p.f.Len()) {
+		// everything has been read, so reuse the buffer
+		p.f.Reset()
+		p.rpos = 0
+	} else if p.rpos > int64(p.f.Len())/2 {
+		// most of the buffer has been read, so discard it, in case the writer never lets the reader catch up
+		p.f.Compact()
+		p.rpos = 0
+	}
+	return n, nil
+}
+
+// Write implements io.Writer
+//
+// It returns an error wrapping io.ErrClosedPipe if CloseWrite was called.
+func (p *PipeFile) Write(s []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return 0, &MemioError{Op: "PipeFile.Write", Err: io.ErrClosedPipe}
+	}
+	p.f.Seek(0, io.SeekEnd)
+	n, _ := p.f.Write(s)
+	p.cond.Broadcast()
+	return n, nil
+}
+
+// WriteString implements io.StringWriter
+//
+// It returns an error wrapping io.ErrClosedPipe if CloseWrite was called.
+func (p *PipeFile) WriteString(s string) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return 0, &MemioError{Op: "PipeFile.WriteString", Err: io.ErrClosedPipe}
+	}
+	p.f.Seek(0, io.SeekEnd)
+	n, _ := p.f.WriteString(s)
+	p.cond.Broadcast()
+	return n, nil
+}
+
+// CloseWrite closes the write side of the pipe
+//
+// Subsequent reads return the remaining data, then io.EOF.
+func (p *PipeFile) CloseWrite() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	p.cond.Broadcast()
+	return nil
+}
+
+// NewPipeFile returns a new empty PipeFile
+func NewPipeFile() *PipeFile {
+	p := &PipeFile{}
+	p.cond.L = &p.mu
+	return p
+}
@@ -0,0 +1,52 @@
+package memio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestPipeFile(t *testing.T) {
+	p := NewPipeFile()
+	exp := &strings.Builder{}
+	go func() {
+		for i := 0; i < 1000; i++ {
+			fmt.Fprintf(p, "line %d\n", i)
+		}
+		p.CloseWrite()
+	}()
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(exp, "line %d\n", i)
+	}
+
+	s, err := io.ReadAll(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(s); got != exp.String() {
+		t.Fatalf("Expected %d bytes; Got %d", exp.Len(), len(got))
+	}
+	if _, err := p.WriteString("x"); !errors.Is(err, io.ErrClosedPipe) {
+		t.Fatalf("Expected io.ErrClosedPipe; Got %v", err)
+	}
+}
+
+func TestPipeFileCompact(t *testing.T) {
+	p := NewPipeFile()
+	p.WriteString("a")
+	buf := make([]byte, 1)
+	for i := range 1000 {
+		p.WriteString("b")
+		if n, err := p.Read(buf); n != 1 || err != nil {
+			t.Fatalf("%d: Expected (1, nil); Got (%d, %v)", i, n, err)
+		}
+		if p.f.Len() > 4 {
+			t.Fatalf("%d: Expected the buffer to be compacted; Got Len()=%d", i, p.f.Len())
+		}
+	}
+	if exp, got := "b", string(p.f.Remaining()); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
@@ -0,0 +1,36 @@
+package memio
+
+import (
+	"sync"
+)
+
+// maxPoolCap is the maximum capacity of an internal buffer retained by Put
+const maxPoolCap = 64 << 10
+
+var pool = sync.Pool{
+	New: func() any {
+		return &File{}
+	},
+}
+
+// Get returns an empty File from a pool shared by the package
+//
+// It should be returned to the pool with Put when it's no longer needed.
+func Get() *File {
+	return pool.Get().(*File)
+}
+
+// Put resets f and returns it to the pool used by Get
+//
+// The internal buffer is only retained by the pool if the File allocated it itself.
+// If it was passed to NewFile or ResetBytes, it's a Section, it's shared by Fork, f is read-only,
+// or its capacity exceeds 64KiB, it's released instead, so it's never written to by a later user.
+// f must not be used after calling Put.
+func Put(f *File) {
+	buf := f.buf[:0]
+	if cap(buf) > maxPoolCap || f.shared || f.readOnly || f.borrowed {
+		buf = nil
+	}
+	*f = File{buf: buf}
+	pool.Put(f)
+}
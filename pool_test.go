@@ -0,0 +1,61 @@
+package memio
+
+import (
+	"testing"
+)
+
+func TestPool(t *testing.T) {
+	f := Get()
+	f.WriteString("hello")
+	f.SetAppend(true)
+	Put(f)
+
+	f = Get()
+	if f.Len() != 0 || f.Offset() != 0 || f.appendMode {
+		t.Fatalf("Expected a reset File; Got Len()=%d, Offset()=%d", f.Len(), f.Offset())
+	}
+
+	f.Grow(maxPoolCap + 1)
+	Put(f)
+	if f.buf != nil {
+		t.Fatalf("Expected oversized buffer to be released; Got capacity %d", cap(f.buf))
+	}
+}
+
+func TestPutReadOnly(t *testing.T) {
+	for name, f := range map[string]*File{
+		"NewReadOnly": NewReadOnly(make([]byte, 5, 8)),
+		"ResetString": Get().ResetString("hello"),
+	} {
+		Put(f)
+		if f.buf != nil {
+			t.Fatalf("%s: Expected read-only buffer to be released; Got %q", name, f.buf)
+		}
+	}
+}
+
+func TestPutBorrowed(t *testing.T) {
+	parent := NewFile([]byte("0123456789"))
+	caller := make([]byte, 0, 8)
+	for name, f := range map[string]*File{
+		"Section":    parent.Section(2, 4),
+		"NewFile":    NewFile(caller),
+		"ResetBytes": Get().ResetBytes(caller),
+	} {
+		Put(f)
+		if f.buf != nil {
+			t.Fatalf("%s: Expected borrowed buffer to be released; Got capacity %d", name, cap(f.buf))
+		}
+	}
+	Get().WriteString("XX")
+	if exp, got := "0123456789", parent.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	f := Get()
+	f.WriteString("owned")
+	Put(f)
+	if f.buf == nil {
+		t.Fatalf("Expected an allocated buffer to be retained")
+	}
+}
@@ -0,0 +1,142 @@
+package memio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/fs"
+	"math"
+)
+
+// ReadLenPrefixed reads a length of prefixSize (2 or 4) bytes in the byte order specified by o,
+// followed by that many bytes, which are returned as a copy
+//
+// If fewer bytes remain than the length specifies, an error wrapping io.ErrUnexpectedEOF is returned.
+// If an error is returned, the current position is unchanged, like ReadFrame.
+func (f *File) ReadLenPrefixed(o binary.ByteOrder, prefixSize int) ([]byte, error) {
+	if f.closed {
+		return nil, &MemioError{Op: "File.ReadLenPrefixed", Err: fs.ErrClosed}
+	}
+	if prefixSize != 2 && prefixSize != 4 {
+		return nil, &MemioError{Op: "File.ReadLenPrefixed", Err: fmt.Errorf("unsupported prefix size(%d): %w", prefixSize, fs.ErrInvalid)}
+	}
+	s := f.buf[f.pos:]
+	if len(s) < prefixSize {
+		return nil, &MemioError{Op: "File.ReadLenPrefixed", Err: ErrShortBuffer}
+	}
+	n := uint64(0)
+	if prefixSize == 2 {
+		n = uint64(o.Uint16(s))
+	} else {
+		n = uint64(o.Uint32(s))
+	}
+	if n > uint64(len(s)-prefixSize) {
+		return nil, &MemioError{Op: "File.ReadLenPrefixed", Err: ErrShortBuffer}
+	}
+	p := f.consume(prefixSize + int(n))
+	return append([]byte(nil), p[prefixSize:]...), nil
+}
+
+// WriteLenPrefixed writes len(p) as a prefixSize (2 or 4) bytes number in the byte order specified by o, followed by p
+//
+// An error wrapping fs.ErrInvalid is returned if prefixSize is not supported, or len(p) doesn't fit in it.
+func (f *File) WriteLenPrefixed(o binary.ByteOrder, prefixSize int, p []byte) error {
+	if f.closed {
+		return &MemioError{Op: "File.WriteLenPrefixed", Err: fs.ErrClosed}
+	}
+	if f.readOnly {
+		return &MemioError{Op: "File.WriteLenPrefixed", Err: fs.ErrPermission}
+	}
+	limit := uint64(0)
+	switch prefixSize {
+	case 2:
+		limit = math.MaxUint16
+	case 4:
+		limit = math.MaxUint32
+	default:
+		return &MemioError{Op: "File.WriteLenPrefixed", Err: fmt.Errorf("unsupported prefix size(%d): %w", prefixSize, fs.ErrInvalid)}
+	}
+	if uint64(len(p)) > limit {
+		return &MemioError{Op: "File.WriteLenPrefixed", Err: fmt.Errorf("length(%d) overflows prefix size(%d): %w", len(p), prefixSize, fs.ErrInvalid)}
+	}
+	if f.sizeRoom()-prefixSize < len(p) {
+		return &MemioError{Op: "File.WriteLenPrefixed", Err: ErrMaxSizeExceeded}
+	}
+	if prefixSize == 2 {
+		f.WriteUint16(o, uint16(len(p)))
+	} else {
+		f.WriteUint32(o, uint32(len(p)))
+	}
+	f.Write(p)
+	return nil
+}
+
+// ReadVarString reads a uvarint length, followed by that many bytes, which are returned as a string
+//
+// The length is checked against the remaining bytes before allocating,
+// and an error wrapping io.ErrUnexpectedEOF is returned if it exceeds them.
+// An error wrapping fs.ErrInvalid is returned if the length overflows a uint64.
+func (f *File) ReadVarString() (string, error) {
+	if f.closed {
+		return "", &MemioError{Op: "File.ReadVarString", Err: fs.ErrClosed}
+	}
+	v, n := binary.Uvarint(f.buf[f.pos:])
+	if n < 0 {
+		return "", &MemioError{Op: "File.ReadVarString", Err: fmt.Errorf("length overflows uint64: %w", fs.ErrInvalid)}
+	}
+	if n == 0 {
+		f.consume(f.RemainingLen())
+		return "", &MemioError{Op: "File.ReadVarString", Err: ErrShortBuffer}
+	}
+	f.consume(n)
+	if v > uint64(f.RemainingLen()) {
+		f.consume(f.RemainingLen())
+		return "", &MemioError{Op: "File.ReadVarString", Err: ErrShortBuffer}
+	}
+	return string(f.consume(int(v))), nil
+}
+
+// WriteVarString writes len(s) as a uvarint, followed by s
+func (f *File) WriteVarString(s string) {
+	p := [binary.MaxVarintLen64]byte{}
+	n := binary.PutUvarint(p[:], uint64(len(s)))
+	b := f.Expand(n + len(s))
+	copy(b[copy(b, p[:n]):], s)
+}
+
+// WriteFrame writes a frame: len(payload) as a 4-byte number in the byte order specified by o, followed by payload
+//
+// It's equivalent to WriteLenPrefixed(o, 4, payload).
+func (f *File) WriteFrame(o binary.ByteOrder, payload []byte) error {
+	if err := f.WriteLenPrefixed(o, 4, payload); err != nil {
+		return wrapErr("File.WriteFrame", err)
+	}
+	return nil
+}
+
+// ReadFrame reads a frame written by WriteFrame, and returns a copy of its payload
+//
+// The length is validated before allocating: an error wrapping ErrLimitExceeded is returned if it exceeds max,
+// and an error wrapping io.ErrUnexpectedEOF is returned if the frame is truncated.
+// If an error is returned, the current position is unchanged, like ReadLenPrefixed,
+// so the frame can be read again after more data is written.
+func (f *File) ReadFrame(o binary.ByteOrder, max int) ([]byte, error) {
+	if f.closed {
+		return nil, &MemioError{Op: "File.ReadFrame", Err: fs.ErrClosed}
+	}
+	if max < 0 {
+		return nil, &MemioError{Op: "File.ReadFrame", Err: fmt.Errorf("negative max(%d): %w", max, fs.ErrInvalid)}
+	}
+	s := f.buf[f.pos:]
+	if len(s) < 4 {
+		return nil, &MemioError{Op: "File.ReadFrame", Err: ErrShortBuffer}
+	}
+	n := uint64(o.Uint32(s))
+	if n > uint64(max) {
+		return nil, &MemioError{Op: "File.ReadFrame", Err: fmt.Errorf("frame length(%d) exceeds max(%d): %w", n, max, ErrLimitExceeded)}
+	}
+	if n > uint64(len(s)-4) {
+		return nil, &MemioError{Op: "File.ReadFrame", Err: ErrShortBuffer}
+	}
+	p := f.consume(4 + int(n))
+	return append([]byte(nil), p[4:]...), nil
+}
@@ -0,0 +1,129 @@
+package memio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestLenPrefixed(t *testing.T) {
+	f := &File{}
+	if err := f.WriteLenPrefixed(binary.BigEndian, 2, []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.WriteLenPrefixed(binary.LittleEndian, 4, []byte("world")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.WriteLenPrefixed(binary.BigEndian, 3, nil); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+	}
+	if exp, got := "\x00\x05hello\x05\x00\x00\x00world", f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	f.Rewind()
+	if p, err := f.ReadLenPrefixed(binary.BigEndian, 2); err != nil || string(p) != "hello" {
+		t.Fatalf("Expected (%q, nil); Got (%q, %v)", "hello", p, err)
+	}
+	if p, err := f.ReadLenPrefixed(binary.LittleEndian, 4); err != nil || string(p) != "world" {
+		t.Fatalf("Expected (%q, nil); Got (%q, %v)", "world", p, err)
+	}
+
+	f = NewFile([]byte("\x00\x00\x00\x09short"))
+	if _, err := f.ReadLenPrefixed(binary.BigEndian, 4); !errors.Is(err, io.ErrUnexpectedEOF) || f.Offset() != 0 {
+		t.Fatalf("Expected (0, io.ErrUnexpectedEOF); Got (%d, %v)", f.Offset(), err)
+	}
+	f.Seek(0, io.SeekEnd)
+	f.WriteString("ened")
+	f.Rewind()
+	if p, err := f.ReadLenPrefixed(binary.BigEndian, 4); err != nil || string(p) != "shortened" {
+		t.Fatalf("Expected (%q, nil); Got (%q, %v)", "shortened", p, err)
+	}
+	f = NewFile([]byte("\x00"))
+	if _, err := f.ReadLenPrefixed(binary.BigEndian, 2); !errors.Is(err, io.ErrUnexpectedEOF) || f.Offset() != 0 {
+		t.Fatalf("Expected (0, io.ErrUnexpectedEOF); Got (%d, %v)", f.Offset(), err)
+	}
+	f = NewFile([]byte("\x00\x09short"))
+	_, err := f.ReadLenPrefixed(binary.BigEndian, 2)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected io.ErrUnexpectedEOF; Got %v", err)
+	}
+	if exp, got := "File.ReadLenPrefixed: "+ErrShortBuffer.Error(), err.Error(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
+
+func TestVarString(t *testing.T) {
+	f := &File{}
+	long := strings.Repeat("x", 300)
+	f.WriteVarString("hello")
+	f.WriteVarString("")
+	f.WriteVarString(long)
+	if exp, got := "\x05hello\x00\xac\x02"+long, f.StringRef(); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+
+	f.Rewind()
+	for _, exp := range []string{"hello", "", long} {
+		if s, err := f.ReadVarString(); err != nil || s != exp {
+			t.Fatalf("Expected (%q, nil); Got (%q, %v)", exp, s, err)
+		}
+	}
+	if _, err := f.ReadVarString(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected io.ErrUnexpectedEOF; Got %v", err)
+	}
+
+	f = NewFile([]byte("\xff\xff\xff\xff\x0fshort"))
+	if _, err := f.ReadVarString(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected io.ErrUnexpectedEOF; Got %v", err)
+	}
+	f = NewFile([]byte("\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01"))
+	if _, err := f.ReadVarString(); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected fs.ErrInvalid; Got %v", err)
+	}
+}
+
+func TestFrame(t *testing.T) {
+	f := &File{}
+	for _, p := range []string{"hello", "", "world!"} {
+		if err := f.WriteFrame(binary.LittleEndian, []byte(p)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f.Rewind()
+	for _, exp := range []string{"hello", "", "world!"} {
+		if p, err := f.ReadFrame(binary.LittleEndian, 6); err != nil || string(p) != exp {
+			t.Fatalf("Expected (%q, nil); Got (%q, %v)", exp, p, err)
+		}
+	}
+	if _, err := f.ReadFrame(binary.LittleEndian, 6); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected io.ErrUnexpectedEOF; Got %v", err)
+	}
+
+	f = &File{}
+	f.WriteFrame(binary.BigEndian, []byte("too long"))
+	f.WriteFrame(binary.BigEndian, []byte("partial"))
+	f.Truncate(f.Len() - 1).Rewind()
+	if _, err := f.ReadFrame(binary.BigEndian, 7); !errors.Is(err, ErrLimitExceeded) || f.Offset() != 0 {
+		t.Fatalf("Expected (0, ErrLimitExceeded); Got (%d, %v)", f.Offset(), err)
+	}
+	f.ReadFrame(binary.BigEndian, 8)
+	if _, err := f.ReadFrame(binary.BigEndian, 8); !errors.Is(err, io.ErrUnexpectedEOF) || f.Offset() != 12 {
+		t.Fatalf("Expected (12, io.ErrUnexpectedEOF); Got (%d, %v)", f.Offset(), err)
+	}
+	f.Seek(0, io.SeekEnd)
+	f.WriteString("l")
+	f.Seek(12, io.SeekStart)
+	if p, err := f.ReadFrame(binary.BigEndian, 8); err != nil || string(p) != "partial" {
+		t.Fatalf("Expected (%q, nil); Got (%q, %v)", "partial", p, err)
+	}
+
+	err := NewReadOnly(nil).WriteFrame(binary.BigEndian, []byte("x"))
+	if exp, got := "File.WriteFrame: "+fs.ErrPermission.Error(), fmt.Sprint(err); got != exp {
+		t.Fatalf("Expected %q; Got %q", exp, got)
+	}
+}
@@ -0,0 +1,69 @@
+package memio
+
+import (
+	"bytes"
+	"iter"
+)
+
+// Scanner reads a File line by line, keeping track of the line number and column for diagnostics
+//
+// Lines should only be read through the Scanner; reading past the end of the current line with File directly
+// skips the bytes read, but they're not counted as lines.
+type Scanner struct {
+	File *File
+	// line is the number of the current line, starting from 1
+	line int
+	// start is the offset of the current line in the internal buffer
+	start int
+}
+
+// NewScanner returns a new Scanner that reads lines from the current position of f
+func (f *File) NewScanner() *Scanner {
+	return &Scanner{File: f, start: f.pos}
+}
+
+// Lines returns an iterator over the remaining lines and their line numbers, starting from 1
+//
+// Each line excludes the trailing "\n" or "\r\n" and is a slice of the internal buffer, so it's invalidated by subsequent writes.
+// The final line is yielded even if it's not terminated by a newline.
+//
+// While each line is yielded, the position of File is at the start of the line, so it may be used to parse the line,
+// and Column reports the position within it. The position is then advanced to the start of the next line,
+// unless the loop is stopped, in which case it's left unchanged.
+// Iteration stops if File is closed, including by the loop body.
+func (s *Scanner) Lines() iter.Seq2[int, []byte] {
+	return func(yield func(int, []byte) bool) {
+		f := s.File
+		for !f.closed && f.pos < len(f.buf) {
+			s.line++
+			s.start = f.pos
+			p, end := f.buf[f.pos:], len(f.buf)
+			if i := bytes.IndexByte(p, '\n'); i >= 0 {
+				p, end = p[:i], f.pos+i+1
+			}
+			if !yield(s.line, bytes.TrimSuffix(p, []byte{'\r'})) {
+				return
+			}
+			if !f.closed && f.pos < end {
+				f.consume(end - f.pos)
+			}
+		}
+	}
+}
+
+// Line returns the number of the current line, starting from 1, or 0 if no line has been read
+func (s *Scanner) Line() int {
+	return s.line
+}
+
+// Column returns the column of the current position of File within the current line, starting from 1
+//
+// The column is counted in bytes, not runes.
+func (s *Scanner) Column() int {
+	return s.File.pos - s.start + 1
+}
+
+// LineOffset returns the offset of the start of the current line in the internal buffer
+func (s *Scanner) LineOffset() int64 {
+	return int64(s.start)
+}
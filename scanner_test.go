@@ -0,0 +1,67 @@
+package memio
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestScannerLines(t *testing.T) {
+	f := NewFile([]byte("skip\r\na = 1\r\n\nb = 2"))
+	f.ReadLine()
+	s := f.NewScanner()
+	if s.Line() != 0 || s.Column() != 1 {
+		t.Fatalf("Expected line 0, col 1; Got line %d, col %d", s.Line(), s.Column())
+	}
+	nums, lines := []int{}, []string{}
+	for n, line := range s.Lines() {
+		nums = append(nums, n)
+		lines = append(lines, string(line))
+	}
+	if exp := []int{1, 2, 3}; !slices.Equal(nums, exp) {
+		t.Fatalf("Expected line numbers %v; Got %v", exp, nums)
+	}
+	if exp := []string{"a = 1", "", "b = 2"}; !slices.Equal(lines, exp) {
+		t.Fatalf("Expected lines %q; Got %q", exp, lines)
+	}
+	if f.RemainingLen() != 0 {
+		t.Fatalf("Expected no bytes remaining; Got %d", f.RemainingLen())
+	}
+}
+
+func TestScannerColumn(t *testing.T) {
+	f := NewFile([]byte("a = 1\nb = x\nc = 3\n"))
+	s := f.NewScanner()
+	for _, line := range s.Lines() {
+		f.ReadBytesInc('=')
+		f.ReadN(1)
+		if v, _ := f.ReadByte(); v < '0' || v > '9' {
+			f.UnreadByte()
+			break
+		}
+		if string(line) == "c = 3" {
+			t.Fatalf("Expected to stop at line 2")
+		}
+	}
+	if s.Line() != 2 || s.Column() != 5 || s.LineOffset() != 6 {
+		t.Fatalf("Expected line 2, col 5, offset 6; Got line %d, col %d, offset %d", s.Line(), s.Column(), s.LineOffset())
+	}
+	if got := f.Offset(); got != 10 {
+		t.Fatalf("Expected position to be left at 10; Got %d", got)
+	}
+}
+
+func TestScannerLinesClosed(t *testing.T) {
+	f := NewFile([]byte("a\nb\nc\n")).SetStrictClose(true)
+	s := f.NewScanner()
+	lines := []string{}
+	for _, line := range s.Lines() {
+		lines = append(lines, string(line))
+		f.Close()
+	}
+	if exp := []string{"a"}; !slices.Equal(lines, exp) {
+		t.Fatalf("Expected lines %q; Got %q", exp, lines)
+	}
+	for range s.Lines() {
+		t.Fatalf("Expected no lines from a closed File")
+	}
+}
@@ -0,0 +1,98 @@
+package memio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/fs"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// structSizes caches the result of nativeStructSize for each type
+var structSizes sync.Map
+
+// ReadStruct reads structured binary data into ptr, like binary.Read
+//
+// If ptr points to a fixed-size struct without padding, and o is the native byte order,
+// the data is copied directly into the struct's memory, avoiding binary.Read's reflection overhead.
+// An error wrapping fs.ErrInvalid is returned, and nothing is read, if ptr is a nil pointer.
+func (f *File) ReadStruct(o binary.ByteOrder, ptr any) error {
+	if f.closed {
+		return &MemioError{Op: "File.ReadStruct", Err: fs.ErrClosed}
+	}
+	if v := reflect.ValueOf(ptr); v.Kind() == reflect.Pointer && v.IsNil() {
+		return &MemioError{Op: "File.ReadStruct", Err: fmt.Errorf("nil pointer(%T): %w", ptr, fs.ErrInvalid)}
+	}
+	if size := nativeSize(o, ptr); size > 0 {
+		if n := len(f.buf) - f.pos; n < size {
+			err := ErrShortBuffer
+			if n <= 0 {
+				err = io.EOF
+			}
+			f.consume(n)
+			return &MemioError{Op: "File.ReadStruct", Err: err}
+		}
+		dst := unsafe.Slice((*byte)(reflect.ValueOf(ptr).UnsafePointer()), size)
+		copy(dst, f.consume(size))
+		return nil
+	}
+	if err := binary.Read(f, o, ptr); err != nil {
+		return &MemioError{Op: "File.ReadStruct", Err: err}
+	}
+	return nil
+}
+
+// nativeSize returns the size of the struct pointed to by ptr,
+// or 0 if it can't be read by copying its memory in byte order o
+func nativeSize(o binary.ByteOrder, ptr any) int {
+	if !isNativeOrder(o) {
+		return 0
+	}
+	t := reflect.TypeOf(ptr)
+	if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		return 0
+	}
+	if size, ok := structSizes.Load(t); ok {
+		return size.(int)
+	}
+	size := nativeStructSize(t.Elem())
+	structSizes.Store(t, size)
+	return size
+}
+
+// nativeStructSize returns the size of struct type t if its memory layout matches its binary encoding, or 0 otherwise
+func nativeStructSize(t reflect.Type) int {
+	if !isNativeType(t) {
+		return 0
+	}
+	size := binary.Size(reflect.Zero(t).Interface())
+	if size <= 0 || uintptr(size) != t.Size() {
+		// not fixed-size, or has padding
+		return 0
+	}
+	return size
+}
+
+// isNativeType reports whether any bit pattern of t's memory is a valid value of t
+func isNativeType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return isNativeType(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			// binary.Read skips blank fields
+			if sf := t.Field(i); sf.Name == "_" || !isNativeType(sf.Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,85 @@
+package memio
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+)
+
+type testHeader struct {
+	Magic   [4]byte
+	Version uint32
+	Size    int64
+	Scale   float64
+}
+
+type testPadded struct {
+	A uint8
+	B uint32
+}
+
+func TestReadStruct(t *testing.T) {
+	for _, o := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian, binary.NativeEndian} {
+		exp := testHeader{Magic: [4]byte{'m', 'e', 'm', 'o'}, Version: 2, Size: -3, Scale: 1.5}
+		f := &File{}
+		binary.Write(f, o, exp)
+		binary.Write(f, o, testPadded{A: 1, B: 2})
+		f.Rewind()
+
+		got := testHeader{}
+		if err := f.ReadStruct(o, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got != exp {
+			t.Fatalf("Expected %+v; Got %+v", exp, got)
+		}
+
+		p := testPadded{}
+		if err := f.ReadStruct(o, &p); err != nil {
+			t.Fatal(err)
+		}
+		if p != (testPadded{A: 1, B: 2}) {
+			t.Fatalf("Expected %+v; Got %+v", testPadded{A: 1, B: 2}, p)
+		}
+
+		if err := f.ReadStruct(o, &got); !errors.Is(err, io.EOF) {
+			t.Fatalf("Expected io.EOF; Got %v", err)
+		}
+	}
+}
+
+func BenchmarkReadStruct(b *testing.B) {
+	f := &File{}
+	binary.Write(f, binary.NativeEndian, testHeader{Version: 1})
+	h := testHeader{}
+
+	b.Run("ReadStruct", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := f.Rewind().ReadStruct(binary.NativeEndian, &h); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("binary.Read", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := binary.Read(f.Rewind(), binary.NativeEndian, &h); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestReadStructNil(t *testing.T) {
+	f := NewFile(make([]byte, 32))
+	var p *testHeader
+	if err := f.ReadStruct(binary.NativeEndian, p); !errors.Is(err, fs.ErrInvalid) {
+		t.Fatalf("Expected error wrapping fs.ErrInvalid; Got %v", err)
+	}
+	if f.Offset() != 0 {
+		t.Fatalf("Expected offset 0; Got %d", f.Offset())
+	}
+}
@@ -0,0 +1,66 @@
+package memio
+
+import (
+	"fmt"
+	"sync"
+)
+
+// SyncFile wraps a File for concurrent appending and snapshotting
+//
+// Only the methods defined on SyncFile are safe for concurrent use.
+// Other File methods may be called while holding the lock via Lock and Unlock.
+type SyncFile struct {
+	*File
+	sync.Mutex
+}
+
+// Write implements io.Writer
+func (s *SyncFile) Write(p []byte) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.File.Write(p)
+}
+
+// WriteString implements io.StringWriter
+func (s *SyncFile) WriteString(p string) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.File.WriteString(p)
+}
+
+// WriteByte implements io.ByteWriter
+func (s *SyncFile) WriteByte(p byte) error {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.File.WriteByte(p)
+}
+
+// Printf writes the result of fmt.Sprintf(format, a...) as a single write
+func (s *SyncFile) Printf(format string, a ...any) *SyncFile {
+	s.Lock()
+	defer s.Unlock()
+
+	fmt.Fprintf(s.File, format, a...)
+	return s
+}
+
+// Bytes returns a copy of the internal buffer
+func (s *SyncFile) Bytes() []byte {
+	s.Lock()
+	defer s.Unlock()
+
+	return append([]byte(nil), s.File.Bytes()...)
+}
+
+// NewSyncFile returns a new SyncFile wrapping f
+//
+// If f is nil, a new empty File is used.
+func NewSyncFile(f *File) *SyncFile {
+	if f == nil {
+		f = &File{}
+	}
+	return &SyncFile{File: f}
+}
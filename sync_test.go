@@ -0,0 +1,36 @@
+package memio
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSyncFile(t *testing.T) {
+	f := NewSyncFile(nil)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				f.WriteString("a")
+				f.WriteByte('b')
+				f.Write([]byte("c"))
+				f.Printf("%s", "d")
+				_ = f.Bytes()
+			}
+		}()
+	}
+	wg.Wait()
+
+	s := string(f.Bytes())
+	if exp, got := 8*100*4, len(s); got != exp {
+		t.Fatalf("Expected %d bytes; Got %d", exp, got)
+	}
+	for _, c := range "abcd" {
+		if exp, got := 8*100, strings.Count(s, string(c)); got != exp {
+			t.Fatalf("Expected %d `%c`; Got %d", exp, c, got)
+		}
+	}
+}